@@ -38,10 +38,10 @@ func main() {
 
 	transaction := rpc.Transaction{
 		Source:      key.PubKey.GetPublicKey(),
-		Fee:         "2941",
+		Fee:         rpc.NewMutez(2941),
 		GasLimit:    "26283",
 		Counter:     strconv.Itoa(counter),
-		Amount:      "0",
+		Amount:      rpc.Mutez{},
 		Destination: "<some_dest>",
 	}
 
@@ -0,0 +1,982 @@
+package forge
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/goat-systems/go-tezos/v4/internal/crypto"
+	"github.com/goat-systems/go-tezos/v4/rpc"
+	"github.com/pkg/errors"
+)
+
+var (
+	nonceHashPrefix     []byte = []byte{13, 15, 58, 7}
+	tz1Prefix           []byte = []byte{6, 161, 159}
+	tz2Prefix           []byte = []byte{6, 161, 161}
+	tz3Prefix           []byte = []byte{6, 161, 164}
+	kt1Prefix           []byte = []byte{2, 90, 121}
+	edpkPrefix          []byte = []byte{13, 15, 37, 217}
+	sppkPrefix          []byte = []byte{3, 254, 226, 86}
+	p2pkPrefix          []byte = []byte{3, 178, 139, 127}
+	signatureByteLength int    = 64
+)
+
+/*
+Decode unforges a forged operation back into its branch, contents, and signature. It is the reverse of Encode
+and supports the same operation kinds. If the operation is signed, the signature is returned base58 encoded
+with the generic signature prefix (sig), otherwise signature is empty.
+
+Whether the operation is signed is told by which of the two parses succeeds. An operation that parses both with
+and without its last 64 bytes as a signature is ambiguous and is an error; use DecodeSigned or DecodeUnsigned when
+you know which it is.
+
+Parameters:
+
+	hexOp:
+		The hex encoded forged operation, optionally followed by its signature.
+*/
+func Decode(hexOp string) (branch string, contents []rpc.Content, signature string, err error) {
+	v, err := decodeOperation(hexOp)
+	if err != nil {
+		return "", nil, "", err
+	}
+
+	unsigned, err := unforgeContents(v[32:])
+	var signed []rpc.Content
+	signedErr := errors.New("operation is too short to contain a signature")
+	if len(v) >= 32+signatureByteLength {
+		signed, signedErr = unforgeContents(v[32 : len(v)-signatureByteLength])
+	}
+
+	switch {
+	case err == nil && signedErr == nil:
+		return "", nil, "", errors.New("failed to unforge operation: operation parses both as signed and unsigned, use DecodeSigned or DecodeUnsigned")
+	case err == nil:
+		return crypto.B58cencode(v[:32], branchPrefix), unsigned, "", nil
+	case signedErr == nil:
+		return crypto.B58cencode(v[:32], branchPrefix), signed, crypto.B58cencode(v[len(v)-signatureByteLength:], sigPrefix), nil
+	default:
+		return "", nil, "", errors.Errorf("failed to unforge operation: as unsigned: %s; as signed: %s", err, signedErr)
+	}
+}
+
+/*
+DecodeSigned unforges a signed operation back into its branch, contents, and signature, like Decode but without
+guessing whether the operation is signed. The signature is returned base58 encoded with the generic signature
+prefix (sig).
+
+Parameters:
+
+	hexOp:
+		The hex encoded forged operation followed by its signature.
+*/
+func DecodeSigned(hexOp string) (branch string, contents []rpc.Content, signature string, err error) {
+	v, err := decodeOperation(hexOp)
+	if err != nil {
+		return "", nil, "", err
+	}
+
+	if len(v) < 32+signatureByteLength {
+		return "", nil, "", errors.New("failed to unforge operation: operation is too short to contain a signature")
+	}
+
+	contents, err = unforgeContents(v[32 : len(v)-signatureByteLength])
+	if err != nil {
+		return "", nil, "", errors.Wrap(err, "failed to unforge operation")
+	}
+
+	return crypto.B58cencode(v[:32], branchPrefix), contents, crypto.B58cencode(v[len(v)-signatureByteLength:], sigPrefix), nil
+}
+
+/*
+DecodeUnsigned unforges an unsigned operation back into its branch and contents, like Decode but without guessing
+whether the operation is signed.
+
+Parameters:
+
+	hexOp:
+		The hex encoded forged operation.
+*/
+func DecodeUnsigned(hexOp string) (branch string, contents []rpc.Content, err error) {
+	v, err := decodeOperation(hexOp)
+	if err != nil {
+		return "", nil, err
+	}
+
+	contents, err = unforgeContents(v[32:])
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed to unforge operation")
+	}
+
+	return crypto.B58cencode(v[:32], branchPrefix), contents, nil
+}
+
+// decodeOperation decodes a hex encoded operation, which starts with its branch
+func decodeOperation(hexOp string) ([]byte, error) {
+	v, err := hex.DecodeString(hexOp)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unforge operation: invalid hex")
+	}
+
+	if len(v) < 32 {
+		return nil, errors.New("failed to unforge operation: operation is too short to contain a branch")
+	}
+
+	return v, nil
+}
+
+func unforgeContents(v []byte) ([]rpc.Content, error) {
+	r := &reader{buf: v}
+	contents := []rpc.Content{}
+	for r.remaining() > 0 {
+		tag, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+
+		var content rpc.Content
+		switch tag {
+		case 0:
+			content, err = unforgeEndorsement(r)
+		case 1:
+			content, err = unforgeSeedNonceRevelation(r)
+		case 2:
+			content, err = unforgeDoubleEndorsementEvidence(r)
+		case 3:
+			content, err = unforgeDoubleBakingEvidence(r)
+		case 4:
+			content, err = unforgeAccountActivation(r)
+		case 5:
+			content, err = unforgeProposal(r)
+		case 6:
+			content, err = unforgeBallot(r)
+		case 107:
+			content, err = unforgeReveal(r)
+		case 108:
+			content, err = unforgeTransaction(r)
+		case 109:
+			content, err = unforgeOrigination(r)
+		case 110:
+			content, err = unforgeDelegation(r)
+		default:
+			return nil, fmt.Errorf("unsupported operation tag '%d'", tag)
+		}
+
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to unforge operation with tag '%d'", tag)
+		}
+		contents = append(contents, content)
+	}
+
+	if len(contents) == 0 {
+		return nil, errors.New("operation does not have any contents")
+	}
+
+	return contents, nil
+}
+
+func unforgeEndorsement(r *reader) (rpc.Content, error) {
+	level, err := r.int32()
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge level")
+	}
+
+	return rpc.Content{
+		Kind:  rpc.ENDORSEMENT,
+		Level: level,
+	}, nil
+}
+
+func unforgeSeedNonceRevelation(r *reader) (rpc.Content, error) {
+	level, err := r.int32()
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge level")
+	}
+
+	nonce, err := r.next(32)
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge nonce")
+	}
+
+	return rpc.Content{
+		Kind:  rpc.SEEDNONCEREVELATION,
+		Level: level,
+		Nonce: hex.EncodeToString(nonce),
+	}, nil
+}
+
+func unforgeDoubleEndorsementEvidence(r *reader) (rpc.Content, error) {
+	op1, err := unforgeInlinedEndorsement(r)
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge op1")
+	}
+
+	op2, err := unforgeInlinedEndorsement(r)
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge op2")
+	}
+
+	return rpc.Content{
+		Kind: rpc.DOUBLEENDORSEMENTEVIDENCE,
+		Op1:  op1,
+		Op2:  op2,
+	}, nil
+}
+
+func unforgeInlinedEndorsement(r *reader) (*rpc.InlinedEndorsement, error) {
+	v, err := r.array(4)
+	if err != nil {
+		return nil, err
+	}
+	inlined := &reader{buf: v}
+
+	branch, err := inlined.next(32)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unforge branch")
+	}
+
+	tag, err := inlined.byte()
+	if err != nil || tag != 0 {
+		return nil, errors.New("failed to unforge operations: expected an endorsement")
+	}
+
+	level, err := inlined.int32()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unforge level")
+	}
+
+	signature, err := inlined.next(signatureByteLength)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unforge signature")
+	}
+
+	if inlined.remaining() != 0 {
+		return nil, errors.New("unexpected trailing bytes in inlined endorsement")
+	}
+
+	return &rpc.InlinedEndorsement{
+		Branch: crypto.B58cencode(branch, branchPrefix),
+		Operations: &rpc.InlinedEndorsementOperations{
+			Kind:  string(rpc.ENDORSEMENT),
+			Level: level,
+		},
+		Signature: crypto.B58cencode(signature, sigPrefix),
+	}, nil
+}
+
+func unforgeDoubleBakingEvidence(r *reader) (rpc.Content, error) {
+	bh1, err := unforgeBlockHeader(r)
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge bh1")
+	}
+
+	bh2, err := unforgeBlockHeader(r)
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge bh2")
+	}
+
+	return rpc.Content{
+		Kind: rpc.DOUBLEBAKINGEVIDENCE,
+		Bh1:  bh1,
+		Bh2:  bh2,
+	}, nil
+}
+
+func unforgeBlockHeader(r *reader) (*rpc.BlockHeader, error) {
+	v, err := r.array(4)
+	if err != nil {
+		return nil, err
+	}
+	header := &reader{buf: v}
+
+	var bh rpc.BlockHeader
+	if bh.Level, err = header.int32(); err != nil {
+		return nil, errors.Wrap(err, "failed to unforge level")
+	}
+
+	proto, err := header.byte()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unforge proto")
+	}
+	bh.Proto = int(proto)
+
+	predecessor, err := header.next(32)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unforge predecessor")
+	}
+	bh.Predecessor = crypto.B58cencode(predecessor, branchPrefix)
+
+	timestamp, err := header.next(8)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unforge timestamp")
+	}
+	bh.Timestamp = time.Unix(int64(binary.BigEndian.Uint64(timestamp)), 0).UTC()
+
+	validationPass, err := header.byte()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unforge validation_pass")
+	}
+	bh.ValidationPass = int(validationPass)
+
+	operationsHash, err := header.next(32)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unforge operations_hash")
+	}
+	bh.OperationsHash = crypto.B58cencode(operationsHash, operationPrefix)
+
+	fitness, err := header.array(4)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unforge fitness")
+	}
+	fitnessReader := &reader{buf: fitness}
+	bh.Fitness = []string{}
+	for fitnessReader.remaining() > 0 {
+		f, err := fitnessReader.array(4)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to unforge fitness")
+		}
+		bh.Fitness = append(bh.Fitness, hex.EncodeToString(f))
+	}
+
+	context, err := header.next(32)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unforge context")
+	}
+	bh.Context = crypto.B58cencode(context, contextPrefix)
+
+	priority, err := header.next(2)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unforge priority")
+	}
+	bh.Priority = int(binary.BigEndian.Uint16(priority))
+
+	proofOfWorkNonce, err := header.next(8)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unforge proof_of_work_nonce")
+	}
+	bh.ProofOfWorkNonce = hex.EncodeToString(proofOfWorkNonce)
+
+	hasSeedNonceHash, err := header.bool()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unforge seed_nonce_hash")
+	}
+	if hasSeedNonceHash {
+		seedNonceHash, err := header.next(32)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to unforge seed_nonce_hash")
+		}
+		bh.SeedNonceHash = crypto.B58cencode(seedNonceHash, nonceHashPrefix)
+	}
+
+	signature, err := header.next(signatureByteLength)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unforge signature")
+	}
+	bh.Signature = crypto.B58cencode(signature, sigPrefix)
+
+	if header.remaining() != 0 {
+		return nil, errors.New("unexpected trailing bytes in block header")
+	}
+
+	return &bh, nil
+}
+
+func unforgeAccountActivation(r *reader) (rpc.Content, error) {
+	pkh, err := r.next(20)
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge pkh")
+	}
+
+	secret, err := r.next(20)
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge secret")
+	}
+
+	return rpc.Content{
+		Kind:   rpc.ACTIVATEACCOUNT,
+		Pkh:    crypto.B58cencode(pkh, tz1Prefix),
+		Secret: hex.EncodeToString(secret),
+	}, nil
+}
+
+func unforgeProposal(r *reader) (rpc.Content, error) {
+	source, err := unforgeSource(r)
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge source")
+	}
+
+	period, err := r.int32()
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge period")
+	}
+
+	v, err := r.array(4)
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge proposals")
+	}
+	if len(v)%32 != 0 {
+		return rpc.Content{}, errors.New("failed to unforge proposals: invalid length")
+	}
+
+	proposals := []string{}
+	for i := 0; i < len(v); i += 32 {
+		proposals = append(proposals, crypto.B58cencode(v[i:i+32], proposalPrefix))
+	}
+
+	return rpc.Content{
+		Kind:      rpc.PROPOSALS,
+		Source:    source,
+		Period:    period,
+		Proposals: proposals,
+	}, nil
+}
+
+func unforgeBallot(r *reader) (rpc.Content, error) {
+	source, err := unforgeSource(r)
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge source")
+	}
+
+	period, err := r.int32()
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge period")
+	}
+
+	proposal, err := r.next(32)
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge proposal")
+	}
+
+	b, err := r.byte()
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge ballot")
+	}
+
+	ballots := []string{"yay", "nay", "pass"}
+	if int(b) >= len(ballots) {
+		return rpc.Content{}, fmt.Errorf("failed to unforge ballot: invalid ballot '%d'", b)
+	}
+
+	return rpc.Content{
+		Kind:     rpc.BALLOT,
+		Source:   source,
+		Period:   period,
+		Proposal: crypto.B58cencode(proposal, proposalPrefix),
+		Ballot:   ballots[b],
+	}, nil
+}
+
+// unforgeManagerFields reads the source, fee, counter, gas_limit, and storage_limit common to manager operations.
+func unforgeManagerFields(r *reader, kind rpc.Kind) (rpc.Content, error) {
+	content := rpc.Content{Kind: kind}
+
+	var err error
+	if content.Source, err = unforgeSource(r); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge source")
+	}
+
+	if content.Fee, err = r.mutez(); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge fee")
+	}
+
+	if content.Counter, err = r.nat(); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge counter")
+	}
+
+	if content.GasLimit, err = r.nat(); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge gas_limit")
+	}
+
+	if content.StorageLimit, err = r.nat(); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge storage_limit")
+	}
+
+	return content, nil
+}
+
+func unforgeReveal(r *reader) (rpc.Content, error) {
+	content, err := unforgeManagerFields(r, rpc.REVEAL)
+	if err != nil {
+		return rpc.Content{}, err
+	}
+
+	if content.PublicKey, err = unforgePublicKey(r); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge public_key")
+	}
+
+	return content, nil
+}
+
+func unforgeTransaction(r *reader) (rpc.Content, error) {
+	content, err := unforgeManagerFields(r, rpc.TRANSACTION)
+	if err != nil {
+		return rpc.Content{}, err
+	}
+
+	if content.Amount, err = r.mutez(); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge amount")
+	}
+
+	if content.Destination, err = unforgeAddress(r); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge destination")
+	}
+
+	hasParameters, err := r.bool()
+	if err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge parameters")
+	}
+
+	if hasParameters {
+		entrypoint, err := unforgeEntrypoint(r)
+		if err != nil {
+			return rpc.Content{}, errors.Wrap(err, "failed to unforge parameters")
+		}
+
+		value, err := unforgeMichelineArray(r)
+		if err != nil {
+			return rpc.Content{}, errors.Wrap(err, "failed to unforge parameters")
+		}
+
+		content.Parameters = &rpc.Parameters{
+			Entrypoint: entrypoint,
+			Value:      value,
+		}
+	}
+
+	return content, nil
+}
+
+func unforgeOrigination(r *reader) (rpc.Content, error) {
+	content, err := unforgeManagerFields(r, rpc.ORIGINATION)
+	if err != nil {
+		return rpc.Content{}, err
+	}
+
+	if content.Balance, err = r.mutez(); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge balance")
+	}
+
+	if content.Delegate, err = unforgeOptionalDelegate(r); err != nil {
+		return rpc.Content{}, err
+	}
+
+	if content.Script.Code, err = unforgeMichelineArray(r); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge script code")
+	}
+
+	if content.Script.Storage, err = unforgeMichelineArray(r); err != nil {
+		return rpc.Content{}, errors.Wrap(err, "failed to unforge script storage")
+	}
+
+	return content, nil
+}
+
+func unforgeDelegation(r *reader) (rpc.Content, error) {
+	content, err := unforgeManagerFields(r, rpc.DELEGATION)
+	if err != nil {
+		return rpc.Content{}, err
+	}
+
+	if content.Delegate, err = unforgeOptionalDelegate(r); err != nil {
+		return rpc.Content{}, err
+	}
+
+	return content, nil
+}
+
+func unforgeOptionalDelegate(r *reader) (string, error) {
+	hasDelegate, err := r.bool()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to unforge delegate")
+	}
+
+	if !hasDelegate {
+		return "", nil
+	}
+
+	delegate, err := unforgeSource(r)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to unforge delegate")
+	}
+
+	return delegate, nil
+}
+
+func unforgeSource(r *reader) (string, error) {
+	tag, err := r.byte()
+	if err != nil {
+		return "", err
+	}
+
+	hash, err := r.next(20)
+	if err != nil {
+		return "", err
+	}
+
+	switch tag {
+	case 0:
+		return crypto.B58cencode(hash, tz1Prefix), nil
+	case 1:
+		return crypto.B58cencode(hash, tz2Prefix), nil
+	case 2:
+		return crypto.B58cencode(hash, tz3Prefix), nil
+	default:
+		return "", fmt.Errorf("invalid source tag '%d'", tag)
+	}
+}
+
+func unforgeAddress(r *reader) (string, error) {
+	tag, err := r.byte()
+	if err != nil {
+		return "", err
+	}
+
+	switch tag {
+	case 0:
+		return unforgeSource(r)
+	case 1:
+		hash, err := r.next(20)
+		if err != nil {
+			return "", err
+		}
+
+		if padding, err := r.byte(); err != nil || padding != 0 {
+			return "", errors.New("invalid originated address padding")
+		}
+
+		return crypto.B58cencode(hash, kt1Prefix), nil
+	default:
+		return "", fmt.Errorf("invalid address tag '%d'", tag)
+	}
+}
+
+func unforgePublicKey(r *reader) (string, error) {
+	tag, err := r.byte()
+	if err != nil {
+		return "", err
+	}
+
+	switch tag {
+	case 0:
+		v, err := r.next(32)
+		if err != nil {
+			return "", err
+		}
+		return crypto.B58cencode(v, edpkPrefix), nil
+	case 1:
+		v, err := r.next(33)
+		if err != nil {
+			return "", err
+		}
+		return crypto.B58cencode(v, sppkPrefix), nil
+	case 2:
+		v, err := r.next(33)
+		if err != nil {
+			return "", err
+		}
+		return crypto.B58cencode(v, p2pkPrefix), nil
+	default:
+		return "", fmt.Errorf("invalid public key tag '%d'", tag)
+	}
+}
+
+func unforgeEntrypoint(r *reader) (string, error) {
+	tag, err := r.byte()
+	if err != nil {
+		return "", err
+	}
+
+	entrypoints := []string{"default", "root", "do", "set_delegate", "remove_delegate"}
+	if int(tag) < len(entrypoints) {
+		return entrypoints[tag], nil
+	}
+
+	if tag != 255 {
+		return "", fmt.Errorf("invalid entrypoint tag '%d'", tag)
+	}
+
+	name, err := r.array(1)
+	if err != nil {
+		return "", err
+	}
+
+	return string(name), nil
+}
+
+func unforgeMichelineArray(r *reader) (*json.RawMessage, error) {
+	v, err := r.array(4)
+	if err != nil {
+		return nil, err
+	}
+
+	micheline := &reader{buf: v}
+	buf := bytes.NewBuffer([]byte{})
+	if err := unforgeMicheline(micheline, buf); err != nil {
+		return nil, err
+	}
+
+	if micheline.remaining() != 0 {
+		return nil, errors.New("unexpected trailing bytes in micheline")
+	}
+
+	raw := json.RawMessage(buf.Bytes())
+	return &raw, nil
+}
+
+// unforgeMicheline is the reverse of forgeMicheline and writes a single micheline expression as json to buf.
+func unforgeMicheline(r *reader, buf *bytes.Buffer) error {
+	tag, err := r.byte()
+	if err != nil {
+		return errors.Wrap(err, "failed to unforge micheline")
+	}
+
+	switch tag {
+	case 0x00:
+		i, err := r.integer()
+		if err != nil {
+			return errors.Wrap(err, "failed to unforge micheline int")
+		}
+		fmt.Fprintf(buf, `{"int":"%s"}`, i)
+	case 0x01:
+		v, err := r.array(4)
+		if err != nil {
+			return errors.Wrap(err, "failed to unforge micheline string")
+		}
+		str, _ := json.Marshal(string(v))
+		fmt.Fprintf(buf, `{"string":%s}`, str)
+	case 0x02:
+		v, err := r.array(4)
+		if err != nil {
+			return errors.Wrap(err, "failed to unforge micheline sequence")
+		}
+
+		seq := &reader{buf: v}
+		buf.WriteByte('[')
+		for i := 0; seq.remaining() > 0; i++ {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := unforgeMicheline(seq, buf); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case 0x03, 0x04, 0x05, 0x06, 0x07, 0x08:
+		argsLen := int(tag-0x03) / 2
+		hasAnnots := (tag-0x03)%2 == 1
+		return unforgePrim(r, buf, argsLen, hasAnnots)
+	case 0x09:
+		return unforgePrim(r, buf, -1, true)
+	case 0x0A:
+		v, err := r.array(4)
+		if err != nil {
+			return errors.Wrap(err, "failed to unforge micheline bytes")
+		}
+		fmt.Fprintf(buf, `{"bytes":"%s"}`, hex.EncodeToString(v))
+	default:
+		return fmt.Errorf("failed to unforge micheline: invalid tag '%d'", tag)
+	}
+
+	return nil
+}
+
+// unforgePrim writes a micheline primitive to buf. An argsLen of -1 represents an application with a variable number of arguments.
+func unforgePrim(r *reader, buf *bytes.Buffer, argsLen int, hasAnnots bool) error {
+	tag, err := r.byte()
+	if err != nil {
+		return errors.Wrap(err, "failed to unforge micheline prim")
+	}
+
+	prim, ok := primByTag(tag)
+	if !ok {
+		return fmt.Errorf("failed to unforge micheline prim: unknown prim tag '%d'", tag)
+	}
+	fmt.Fprintf(buf, `{"prim":"%s"`, prim)
+
+	args := r
+	if argsLen == -1 {
+		v, err := r.array(4)
+		if err != nil {
+			return errors.Wrap(err, "failed to unforge micheline args")
+		}
+		args = &reader{buf: v}
+	}
+
+	if argsLen != 0 {
+		buf.WriteString(`,"args":[`)
+		for i := 0; (argsLen == -1 && args.remaining() > 0) || i < argsLen; i++ {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := unforgeMicheline(args, buf); err != nil {
+				return errors.Wrap(err, "failed to unforge micheline args")
+			}
+		}
+		buf.WriteByte(']')
+	}
+
+	if hasAnnots {
+		v, err := r.array(4)
+		if err != nil {
+			return errors.Wrap(err, "failed to unforge micheline annots")
+		}
+
+		if len(v) > 0 {
+			annots, _ := json.Marshal(strings.Split(string(v), " "))
+			fmt.Fprintf(buf, `,"annots":%s`, annots)
+		}
+	}
+
+	buf.WriteByte('}')
+	return nil
+}
+
+func primByTag(tag byte) (string, bool) {
+	for prim, t := range primitives {
+		if t == tag {
+			return prim, true
+		}
+	}
+
+	return "", false
+}
+
+// reader consumes forged bytes in order.
+type reader struct {
+	buf []byte
+	pos int
+}
+
+func (r *reader) remaining() int {
+	return len(r.buf) - r.pos
+}
+
+func (r *reader) next(n int) ([]byte, error) {
+	if n < 0 || r.remaining() < n {
+		return nil, fmt.Errorf("unexpected end of bytes: wanted %d bytes but %d remain", n, r.remaining())
+	}
+
+	v := r.buf[r.pos : r.pos+n]
+	r.pos += n
+	return v, nil
+}
+
+func (r *reader) byte() (byte, error) {
+	v, err := r.next(1)
+	if err != nil {
+		return 0, err
+	}
+
+	return v[0], nil
+}
+
+func (r *reader) bool() (bool, error) {
+	v, err := r.byte()
+	if err != nil {
+		return false, err
+	}
+
+	switch v {
+	case 0:
+		return false, nil
+	case 255:
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid boolean '%d'", v)
+	}
+}
+
+func (r *reader) int32() (int, error) {
+	v, err := r.next(4)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(int32(binary.BigEndian.Uint32(v))), nil
+}
+
+// array reads bytes prefixed by their big endian length of l bytes, the reverse of forgeArray.
+func (r *reader) array(l int) ([]byte, error) {
+	v, err := r.next(l)
+	if err != nil {
+		return nil, err
+	}
+
+	var length uint64
+	for _, b := range v {
+		length = length<<8 | uint64(b)
+	}
+
+	if length > uint64(r.remaining()) {
+		return nil, fmt.Errorf("unexpected end of bytes: wanted %d bytes but %d remain", length, r.remaining())
+	}
+
+	return r.next(int(length))
+}
+
+// nat reads a zarith encoded natural number, the reverse of forgeNat.
+func (r *reader) nat() (string, error) {
+	n, err := r.bigNat()
+	if err != nil {
+		return "", err
+	}
+
+	return n.String(), nil
+}
+
+// mutez reads an amount encoded as a zarith natural number, the reverse of forgeMutez.
+func (r *reader) mutez() (rpc.Mutez, error) {
+	n, err := r.bigNat()
+	if err != nil {
+		return rpc.Mutez{}, err
+	}
+
+	return rpc.NewMutezFromBigInt(n), nil
+}
+
+func (r *reader) bigNat() (*big.Int, error) {
+	n := new(big.Int)
+	for shift := uint(0); ; shift += 7 {
+		b, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+
+		n.Or(n, new(big.Int).Lsh(big.NewInt(int64(b&0x7f)), shift))
+		if b&0x80 == 0 {
+			break
+		}
+	}
+
+	return n, nil
+}
+
+// integer reads a zarith encoded signed integer, the reverse of forgeInt.
+func (r *reader) integer() (string, error) {
+	b, err := r.byte()
+	if err != nil {
+		return "", err
+	}
+
+	negative := b&0x40 != 0
+	n := big.NewInt(int64(b & 0x3f))
+	for shift := uint(6); b&0x80 != 0; shift += 7 {
+		if b, err = r.byte(); err != nil {
+			return "", err
+		}
+
+		n.Or(n, new(big.Int).Lsh(big.NewInt(int64(b&0x7f)), shift))
+	}
+
+	if negative {
+		n.Neg(n)
+	}
+
+	return n.String(), nil
+}
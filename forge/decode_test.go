@@ -0,0 +1,335 @@
+package forge
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goat-systems/go-tezos/v4/internal/crypto"
+	"github.com/goat-systems/go-tezos/v4/internal/testutils"
+	"github.com/goat-systems/go-tezos/v4/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Decode(t *testing.T) {
+	signature := strings.Repeat("ab", 64)
+
+	type want struct {
+		err         bool
+		errContains string
+		branch      string
+		kinds       []rpc.Kind
+		signature   string
+	}
+
+	cases := []struct {
+		name  string
+		input string
+		want  want
+	}{
+		{
+			"is successful with transaction",
+			"5aff622d53d32a8bae591627718c60a35b16737e301c57a13b6f1765483d88ff6c007fd82c06cf5a203f18faaf562447ed1efcc6c010830a07c350008090dfc04a0000a31e81ac3425310e3274a4698a793b2839dc0afa00",
+			want{
+				false,
+				"",
+				"BLQMkH2PSTuAJgVm6rGHshY5z6Z6SAmqXv6q1LDzhX6fchJ12Up",
+				[]rpc.Kind{rpc.TRANSACTION},
+				"",
+			},
+		},
+		{
+			"is successful with multiple transactions and parameters",
+			"452b8599b0e4960b884d3ad61c89c594bc3348c798842651d3fa6cfafa77ce556c007fd82c06cf5a203f18faaf562447ed1efcc6c010830a07c350008090dfc04a0000a31e81ac3425310e3274a4698a793b2839dc0afa006c00490dc9520ec45270f240a3cc4f07aec76adc358d9617b693089fcd01000001fcc0bee1480bfca3a80481904cee4099400b1c8d00ff020000004f020000004a0358053d036d0743035d0100000024747a324c324875686161536e663653684544646854454172356a475057504e7770766342031e0743036a0002034f034d031b051f02000000020320",
+			want{
+				false,
+				"",
+				"BLEkC1TqtP7DJjGnyxwhT8VDnEF75aNMMKS5qJXSTFmAKkV7Pch",
+				[]rpc.Kind{rpc.TRANSACTION, rpc.TRANSACTION},
+				"",
+			},
+		},
+		{
+			"is successful with reveal and transaction",
+			"3f82cf0634a5965032d087daa63cf3603dd0f0325e2d670fee91b20486caa0d36b00d4a35d6c49ffbaa32b40e96c844dc485b0cdb5fae90905904e00004e7097e206a9afa864475095b58009014f9c24efd54c5d40240c1e807b4ab80c6c00d4a35d6c49ffbaa32b40e96c844dc485b0cdb5faa40906c3500080e8eda1ba010000a31e81ac3425310e3274a4698a793b2839dc0afa00",
+			want{
+				false,
+				"",
+				"BLCFdxw2kWJfCk9TWQsYxrQd9CcPPs2YdbArbDDgL4GZTYvTfZN",
+				[]rpc.Kind{rpc.REVEAL, rpc.TRANSACTION},
+				"",
+			},
+		},
+		{
+			"is successful with origination",
+			"5aff622d53d32a8bae591627718c60a35b16737e301c57a13b6f1765483d88ff6d0054013ef6636fe99989a26006622bf270be0b14859610d3de12af7c8e040000000000ef02000000ea0500036c0501036c050202000000db0321051f0200000002031703160743036e01000000244b54314d384d5374774131523553754778325636414d7667643864474146596345556d7505550764085e036c055f036d0000000325646f046c000000082564656661756c74072f020000001807430368010000000d74797065206d69736d6174636803270200000051020000000f071f00020200000002032105700003053d036d020000000f071f00020200000002032105700003071f0003020000000203200743036a0080897a034f0544075e036c055f036d034d031b0342051f02000000040320032000000002030b",
+			want{
+				false,
+				"",
+				"BLQMkH2PSTuAJgVm6rGHshY5z6Z6SAmqXv6q1LDzhX6fchJ12Up",
+				[]rpc.Kind{rpc.ORIGINATION},
+				"",
+			},
+		},
+		{
+			"is successful with signature",
+			"5aff622d53d32a8bae591627718c60a35b16737e301c57a13b6f1765483d88ff6c007fd82c06cf5a203f18faaf562447ed1efcc6c010830a07c350008090dfc04a0000a31e81ac3425310e3274a4698a793b2839dc0afa00" + signature,
+			want{
+				false,
+				"",
+				"BLQMkH2PSTuAJgVm6rGHshY5z6Z6SAmqXv6q1LDzhX6fchJ12Up",
+				[]rpc.Kind{rpc.TRANSACTION},
+				"sigkSwUrbjgRpK7MsEegGBirWapYepUuU8dEZjNzyzXcxLhGiPnBt8C1X3VmefpVqPnbig2NFoBQFLtLDNqUUKzeoV786aTo",
+			},
+		},
+		{
+			"handles invalid hex",
+			"zz",
+			want{
+				true,
+				"invalid hex",
+				"",
+				nil,
+				"",
+			},
+		},
+		{
+			"handles unknown tag",
+			"5aff622d53d32a8bae591627718c60a35b16737e301c57a13b6f1765483d88ff7f",
+			want{
+				true,
+				"unsupported operation tag '127'",
+				"",
+				nil,
+				"",
+			},
+		},
+		{
+			"handles contents that are invalid signed or unsigned",
+			"5aff622d53d32a8bae591627718c60a35b16737e301c57a13b6f1765483d88ff00" + strings.Repeat("7f", 64),
+			want{
+				true,
+				"as unsigned: unsupported operation tag '127'; as signed: failed to unforge operation with tag '0': failed to unforge level",
+				"",
+				nil,
+				"",
+			},
+		},
+		{
+			"handles truncated contents",
+			"5aff622d53d32a8bae591627718c60a35b16737e301c57a13b6f1765483d88ff6c007fd82c06cf5a203f18faaf562447ed",
+			want{
+				true,
+				"unexpected end of bytes",
+				"",
+				nil,
+				"",
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			branch, contents, signature, err := Decode(tt.input)
+			testutils.CheckErr(t, tt.want.err, tt.want.errContains, err)
+			assert.Equal(t, tt.want.branch, branch)
+			assert.Equal(t, tt.want.signature, signature)
+
+			var kinds []rpc.Kind
+			for _, c := range contents {
+				kinds = append(kinds, c.Kind)
+			}
+			assert.Equal(t, tt.want.kinds, kinds)
+
+			if err == nil {
+				operation, err := Encode(branch, contents...)
+				testutils.CheckErr(t, false, "", err)
+				assert.Equal(t, strings.TrimSuffix(tt.input, strings.Repeat("ab", 64)), operation)
+			}
+		})
+	}
+}
+
+func Test_Decode_Contents(t *testing.T) {
+	parameters := json.RawMessage(`[{"prim":"DROP"},{"prim":"PUSH","args":[{"prim":"int"},{"int":"-1024"}],"annots":["@x"]},{"prim":"PUSH","args":[{"prim":"bytes"},{"bytes":"0a0b"}]},{"prim":"PUSH","args":[{"prim":"string"},{"string":"hello world"}]},{"prim":"pair","args":[{"prim":"int"},{"prim":"nat"},{"prim":"unit"}],"annots":["%p"]}]`)
+	code, storage := json.RawMessage(`[{"prim":"parameter","args":[{"prim":"unit"}]},{"prim":"storage","args":[{"prim":"unit"}]},{"prim":"code","args":[[{"prim":"CDR"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]]}]`), json.RawMessage(`{"prim":"Unit"}`)
+	amount, err := rpc.ParseMutez("18446744073709551616000")
+	testutils.CheckErr(t, false, "", err)
+
+	hash := func(b byte, prefix []byte) string {
+		return crypto.B58cencode(bytes.Repeat([]byte{b}, 32), prefix)
+	}
+	signature := crypto.B58cencode(bytes.Repeat([]byte{0xab}, 64), sigPrefix)
+	header := func(priority int, seedNonceHash string) *rpc.BlockHeader {
+		return &rpc.BlockHeader{
+			Level:            1234567,
+			Proto:            8,
+			Predecessor:      hash(1, branchPrefix),
+			Timestamp:        time.Date(2021, time.March, 4, 5, 6, 7, 0, time.UTC),
+			ValidationPass:   4,
+			OperationsHash:   hash(2, operationPrefix),
+			Fitness:          []string{"01", "000000000001d8a1"},
+			Context:          hash(3, contextPrefix),
+			Priority:         priority,
+			ProofOfWorkNonce: "0102030405060708",
+			SeedNonceHash:    seedNonceHash,
+			Signature:        signature,
+		}
+	}
+	inlined := func(level int) *rpc.InlinedEndorsement {
+		return &rpc.InlinedEndorsement{
+			Branch: hash(1, branchPrefix),
+			Operations: &rpc.InlinedEndorsementOperations{
+				Kind:  string(rpc.ENDORSEMENT),
+				Level: level,
+			},
+			Signature: signature,
+		}
+	}
+
+	contents := []rpc.Content{
+		{
+			Kind:  rpc.ENDORSEMENT,
+			Level: 1234567,
+		},
+		{
+			Kind:      rpc.PROPOSALS,
+			Source:    "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc",
+			Period:    42,
+			Proposals: []string{hash(4, proposalPrefix), hash(5, proposalPrefix)},
+		},
+		{
+			Kind:     rpc.BALLOT,
+			Source:   "tz2L2HuhaaSnf6ShEDdhTEAr5jGPWPNwpvcB",
+			Period:   42,
+			Proposal: hash(4, proposalPrefix),
+			Ballot:   "nay",
+		},
+		{
+			Kind: rpc.DOUBLEENDORSEMENTEVIDENCE,
+			Op1:  inlined(1234567),
+			Op2:  inlined(1234568),
+		},
+		{
+			Kind: rpc.DOUBLEBAKINGEVIDENCE,
+			Bh1:  header(0, hash(6, nonceHashPrefix)),
+			Bh2:  header(1, ""),
+		},
+		{
+			Kind:   rpc.ACTIVATEACCOUNT,
+			Pkh:    "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc",
+			Secret: strings.Repeat("0e", 20),
+		},
+		{
+			Kind:         rpc.REVEAL,
+			Source:       "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc",
+			Fee:          rpc.NewMutez(1257),
+			Counter:      "3",
+			GasLimit:     "10000",
+			StorageLimit: "0",
+			PublicKey:    "edpkuEmaQSYKgDj5k9wfE3bTxjfjoG9k5YvRmYZsGf2bjEymZKkzNn",
+		},
+		{
+			Kind:         rpc.ORIGINATION,
+			Source:       "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc",
+			Fee:          rpc.NewMutez(2000),
+			Counter:      "4",
+			GasLimit:     "20000",
+			StorageLimit: "500",
+			Balance:      rpc.NewMutez(1000000),
+			Delegate:     "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc",
+			Script: rpc.Script{
+				Code:    &code,
+				Storage: &storage,
+			},
+		},
+		{
+			Kind:  rpc.SEEDNONCEREVELATION,
+			Level: 32,
+			Nonce: strings.Repeat("0f", 32),
+		},
+		{
+			Kind:         rpc.DELEGATION,
+			Source:       "tz2L2HuhaaSnf6ShEDdhTEAr5jGPWPNwpvcB",
+			Fee:          rpc.NewMutez(1257),
+			Counter:      "5",
+			GasLimit:     "10000",
+			StorageLimit: "0",
+			Delegate:     "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc",
+		},
+		{
+			Kind:         rpc.TRANSACTION,
+			Source:       "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc",
+			Fee:          rpc.NewMutez(9223372036854775807),
+			Counter:      "1",
+			GasLimit:     "10000",
+			StorageLimit: "0",
+			Amount:       rpc.NewMutez(0),
+			Destination:  "KT1XdCkJncWfGvqf1NdbK2HBRTvRcHhJtNx5",
+			Parameters: &rpc.Parameters{
+				Entrypoint: "transfer",
+				Value:      &parameters,
+			},
+		},
+		{
+			Kind:         rpc.TRANSACTION,
+			Source:       "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc",
+			Fee:          rpc.NewMutez(1420),
+			Counter:      "2",
+			GasLimit:     "10600",
+			StorageLimit: "300",
+			Amount:       amount,
+			Destination:  "tz1L8fUQLuwRuywTZUP5JUw9LL3kJa8LMfoo",
+		},
+	}
+
+	operation, err := Encode("BLQMkH2PSTuAJgVm6rGHshY5z6Z6SAmqXv6q1LDzhX6fchJ12Up", contents...)
+	testutils.CheckErr(t, false, "", err)
+
+	branch, decoded, signature, err := Decode(operation)
+	testutils.CheckErr(t, false, "", err)
+	assert.Equal(t, "BLQMkH2PSTuAJgVm6rGHshY5z6Z6SAmqXv6q1LDzhX6fchJ12Up", branch)
+	assert.Equal(t, "", signature)
+	assert.Equal(t, contents, decoded)
+}
+
+func Test_DecodeSigned(t *testing.T) {
+	branch := "BLQMkH2PSTuAJgVm6rGHshY5z6Z6SAmqXv6q1LDzhX6fchJ12Up"
+	endorsement := rpc.Content{Kind: rpc.ENDORSEMENT, Level: 1}
+	ballot := rpc.Content{
+		Kind:     rpc.BALLOT,
+		Source:   "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc",
+		Period:   42,
+		Proposal: crypto.B58cencode(bytes.Repeat([]byte{4}, 32), proposalPrefix),
+		Ballot:   "yay",
+	}
+
+	// a ballot (59 bytes) and an endorsement (5 bytes) are 64 bytes, just like a signature
+	operation, err := Encode(branch, endorsement, ballot, endorsement)
+	testutils.CheckErr(t, false, "", err)
+
+	_, _, _, err = Decode(operation)
+	testutils.CheckErr(t, true, "operation parses both as signed and unsigned", err)
+
+	decodedBranch, contents, err := DecodeUnsigned(operation)
+	testutils.CheckErr(t, false, "", err)
+	assert.Equal(t, branch, decodedBranch)
+	assert.Equal(t, []rpc.Content{endorsement, ballot, endorsement}, contents)
+
+	decodedBranch, contents, signature, err := DecodeSigned(operation)
+	testutils.CheckErr(t, false, "", err)
+	assert.Equal(t, branch, decodedBranch)
+	assert.Equal(t, []rpc.Content{endorsement}, contents)
+	tail, err := hex.DecodeString(operation[64+10:])
+	testutils.CheckErr(t, false, "", err)
+	assert.Equal(t, crypto.B58cencode(tail, sigPrefix), signature)
+
+	_, _, _, err = DecodeSigned(operation[:64+10])
+	testutils.CheckErr(t, true, "operation is too short to contain a signature", err)
+
+	_, _, err = DecodeUnsigned(operation[:64+10] + strings.Repeat("ab", 64))
+	testutils.CheckErr(t, true, "failed to unforge operation", err)
+}
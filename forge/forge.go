@@ -6,16 +6,14 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"math"
 	"math/big"
 	"strconv"
 	"strings"
-	"time"
 
-	"github.com/btcsuite/btcutil/base58"
 	validator "github.com/go-playground/validator/v10"
 	"github.com/goat-systems/go-tezos/v4/internal/crypto"
 	"github.com/goat-systems/go-tezos/v4/rpc"
+	"github.com/goat-systems/go-tezos/v4/tezos"
 	"github.com/pkg/errors"
 	"github.com/valyala/fastjson"
 	"golang.org/x/crypto/blake2b"
@@ -26,10 +24,22 @@ var (
 	proposalPrefix         []byte = []byte{2, 170}
 	sigPrefix              []byte = []byte{4, 130, 43}
 	operationPrefix        []byte = []byte{29, 159, 109}
+	operationHashPrefix    []byte = []byte{5, 116}
 	contextPrefix          []byte = []byte{79, 179}
 	scriptExpressionPrefix []byte = []byte{13, 44, 64, 27}
 )
 
+// validate checks the validate tags of the operations forged, with the validations of the rpc package so that a
+// required fee that was never set is rejected
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+	rpc.RegisterValidations(v)
+
+	return v
+}
+
 func operationTags(kind string) string {
 	tags := map[string]string{
 		"endorsement":                 "0",
@@ -48,129 +58,157 @@ func operationTags(kind string) string {
 	return tags[kind]
 }
 
-func primTags(prim string) byte {
-	tags := map[string]byte{
-		"parameter":        0x00,
-		"storage":          0x01,
-		"code":             0x02,
-		"False":            0x03,
-		"Elt":              0x04,
-		"Left":             0x05,
-		"None":             0x06,
-		"Pair":             0x07,
-		"Right":            0x08,
-		"Some":             0x09,
-		"True":             0x0A,
-		"Unit":             0x0B,
-		"PACK":             0x0C,
-		"UNPACK":           0x0D,
-		"BLAKE2B":          0x0E,
-		"SHA256":           0x0F,
-		"SHA512":           0x10,
-		"ABS":              0x11,
-		"ADD":              0x12,
-		"AMOUNT":           0x13,
-		"AND":              0x14,
-		"BALANCE":          0x15,
-		"CAR":              0x16,
-		"CDR":              0x17,
-		"CHECK_SIGNATURE":  0x18,
-		"COMPARE":          0x19,
-		"CONCAT":           0x1A,
-		"CONS":             0x1B,
-		"CREATE_ACCOUNT":   0x1C,
-		"CREATE_CONTRACT":  0x1D,
-		"IMPLICIT_ACCOUNT": 0x1E,
-		"DIP":              0x1F,
-		"DROP":             0x20,
-		"DUP":              0x21,
-		"EDIV":             0x22,
-		"EMPTY_MAP":        0x23,
-		"EMPTY_SET":        0x24,
-		"EQ":               0x25,
-		"EXEC":             0x26,
-		"FAILWITH":         0x27,
-		"GE":               0x28,
-		"GET":              0x29,
-		"GT":               0x2A,
-		"HASH_KEY":         0x2B,
-		"IF":               0x2C,
-		"IF_CONS":          0x2D,
-		"IF_LEFT":          0x2E,
-		"IF_NONE":          0x2F,
-		"INT":              0x30,
-		"LAMBDA":           0x31,
-		"LE":               0x32,
-		"LEFT":             0x33,
-		"LOOP":             0x34,
-		"LSL":              0x35,
-		"LSR":              0x36,
-		"LT":               0x37,
-		"MAP":              0x38,
-		"MEM":              0x39,
-		"MUL":              0x3A,
-		"NEG":              0x3B,
-		"NEQ":              0x3C,
-		"NIL":              0x3D,
-		"NONE":             0x3E,
-		"NOT":              0x3F,
-		"NOW":              0x40,
-		"OR":               0x41,
-		"PAIR":             0x42,
-		"PUSH":             0x43,
-		"RIGHT":            0x44,
-		"SIZE":             0x45,
-		"SOME":             0x46,
-		"SOURCE":           0x47,
-		"SENDER":           0x48,
-		"SELF":             0x49,
-		"STEPS_TO_QUOTA":   0x4A,
-		"SUB":              0x4B,
-		"SWAP":             0x4C,
-		"TRANSFER_TOKENS":  0x4D,
-		"SET_DELEGATE":     0x4E,
-		"UNIT":             0x4F,
-		"UPDATE":           0x50,
-		"XOR":              0x51,
-		"ITER":             0x52,
-		"LOOP_LEFT":        0x53,
-		"ADDRESS":          0x54,
-		"CONTRACT":         0x55,
-		"ISNAT":            0x56,
-		"CAST":             0x57,
-		"RENAME":           0x58,
-		"bool":             0x59,
-		"contract":         0x5A,
-		"int":              0x5B,
-		"key":              0x5C,
-		"key_hash":         0x5D,
-		"lambda":           0x5E,
-		"list":             0x5F,
-		"map":              0x60,
-		"big_map":          0x61,
-		"nat":              0x62,
-		"option":           0x63,
-		"or":               0x64,
-		"pair":             0x65,
-		"set":              0x66,
-		"signature":        0x67,
-		"string":           0x68,
-		"bytes":            0x69,
-		"mutez":            0x6A,
-		"timestamp":        0x6B,
-		"unit":             0x6C,
-		"operation":        0x6D,
-		"address":          0x6E,
-		"SLICE":            0x6F,
-		"DIG":              0x70,
-		"DUG":              0x71,
-		"EMPTY_BIG_MAP":    0x72,
-		"APPLY":            0x73,
-		"chain_id":         0x74,
-		"CHAIN_ID":         0x75,
-	}
-
-	return tags[prim]
+var primitives = map[string]byte{
+	"parameter":             0x00,
+	"storage":               0x01,
+	"code":                  0x02,
+	"False":                 0x03,
+	"Elt":                   0x04,
+	"Left":                  0x05,
+	"None":                  0x06,
+	"Pair":                  0x07,
+	"Right":                 0x08,
+	"Some":                  0x09,
+	"True":                  0x0A,
+	"Unit":                  0x0B,
+	"PACK":                  0x0C,
+	"UNPACK":                0x0D,
+	"BLAKE2B":               0x0E,
+	"SHA256":                0x0F,
+	"SHA512":                0x10,
+	"ABS":                   0x11,
+	"ADD":                   0x12,
+	"AMOUNT":                0x13,
+	"AND":                   0x14,
+	"BALANCE":               0x15,
+	"CAR":                   0x16,
+	"CDR":                   0x17,
+	"CHECK_SIGNATURE":       0x18,
+	"COMPARE":               0x19,
+	"CONCAT":                0x1A,
+	"CONS":                  0x1B,
+	"CREATE_ACCOUNT":        0x1C,
+	"CREATE_CONTRACT":       0x1D,
+	"IMPLICIT_ACCOUNT":      0x1E,
+	"DIP":                   0x1F,
+	"DROP":                  0x20,
+	"DUP":                   0x21,
+	"EDIV":                  0x22,
+	"EMPTY_MAP":             0x23,
+	"EMPTY_SET":             0x24,
+	"EQ":                    0x25,
+	"EXEC":                  0x26,
+	"FAILWITH":              0x27,
+	"GE":                    0x28,
+	"GET":                   0x29,
+	"GT":                    0x2A,
+	"HASH_KEY":              0x2B,
+	"IF":                    0x2C,
+	"IF_CONS":               0x2D,
+	"IF_LEFT":               0x2E,
+	"IF_NONE":               0x2F,
+	"INT":                   0x30,
+	"LAMBDA":                0x31,
+	"LE":                    0x32,
+	"LEFT":                  0x33,
+	"LOOP":                  0x34,
+	"LSL":                   0x35,
+	"LSR":                   0x36,
+	"LT":                    0x37,
+	"MAP":                   0x38,
+	"MEM":                   0x39,
+	"MUL":                   0x3A,
+	"NEG":                   0x3B,
+	"NEQ":                   0x3C,
+	"NIL":                   0x3D,
+	"NONE":                  0x3E,
+	"NOT":                   0x3F,
+	"NOW":                   0x40,
+	"OR":                    0x41,
+	"PAIR":                  0x42,
+	"PUSH":                  0x43,
+	"RIGHT":                 0x44,
+	"SIZE":                  0x45,
+	"SOME":                  0x46,
+	"SOURCE":                0x47,
+	"SENDER":                0x48,
+	"SELF":                  0x49,
+	"STEPS_TO_QUOTA":        0x4A,
+	"SUB":                   0x4B,
+	"SWAP":                  0x4C,
+	"TRANSFER_TOKENS":       0x4D,
+	"SET_DELEGATE":          0x4E,
+	"UNIT":                  0x4F,
+	"UPDATE":                0x50,
+	"XOR":                   0x51,
+	"ITER":                  0x52,
+	"LOOP_LEFT":             0x53,
+	"ADDRESS":               0x54,
+	"CONTRACT":              0x55,
+	"ISNAT":                 0x56,
+	"CAST":                  0x57,
+	"RENAME":                0x58,
+	"bool":                  0x59,
+	"contract":              0x5A,
+	"int":                   0x5B,
+	"key":                   0x5C,
+	"key_hash":              0x5D,
+	"lambda":                0x5E,
+	"list":                  0x5F,
+	"map":                   0x60,
+	"big_map":               0x61,
+	"nat":                   0x62,
+	"option":                0x63,
+	"or":                    0x64,
+	"pair":                  0x65,
+	"set":                   0x66,
+	"signature":             0x67,
+	"string":                0x68,
+	"bytes":                 0x69,
+	"mutez":                 0x6A,
+	"timestamp":             0x6B,
+	"unit":                  0x6C,
+	"operation":             0x6D,
+	"address":               0x6E,
+	"SLICE":                 0x6F,
+	"DIG":                   0x70,
+	"DUG":                   0x71,
+	"EMPTY_BIG_MAP":         0x72,
+	"APPLY":                 0x73,
+	"chain_id":              0x74,
+	"CHAIN_ID":              0x75,
+	"LEVEL":                 0x76,
+	"SELF_ADDRESS":          0x77,
+	"never":                 0x78,
+	"NEVER":                 0x79,
+	"UNPAIR":                0x7A,
+	"VOTING_POWER":          0x7B,
+	"TOTAL_VOTING_POWER":    0x7C,
+	"KECCAK":                0x7D,
+	"SHA3":                  0x7E,
+	"PAIRING_CHECK":         0x7F,
+	"bls12_381_g1":          0x80,
+	"bls12_381_g2":          0x81,
+	"bls12_381_fr":          0x82,
+	"sapling_state":         0x83,
+	"sapling_transaction":   0x84,
+	"SAPLING_EMPTY_STATE":   0x85,
+	"SAPLING_VERIFY_UPDATE": 0x86,
+	"ticket":                0x87,
+	"TICKET":                0x88,
+	"READ_TICKET":           0x89,
+	"SPLIT_TICKET":          0x8A,
+	"JOIN_TICKETS":          0x8B,
+	"GET_AND_UPDATE":        0x8C,
+}
+
+func primTags(prim string) (byte, error) {
+	tag, ok := primitives[prim]
+	if !ok {
+		return 0, errors.Errorf("unknown primitive '%s'", prim)
+	}
+
+	return tag, nil
 }
 
 /*
@@ -287,7 +325,7 @@ func Encode(branch string, contents ...rpc.Content) (string, error) {
 }
 
 func forgeReveal(r rpc.Reveal) ([]byte, error) {
-	err := validator.New().Struct(r)
+	err := validate.Struct(r)
 	if err != nil {
 		return []byte{}, errors.Wrap(err, "invalid input")
 	}
@@ -306,7 +344,7 @@ func forgeReveal(r rpc.Reveal) ([]byte, error) {
 		return []byte{}, errors.Wrap(err, "failed to forge source")
 	}
 
-	if fee, err := forgeNat(r.Fee); err == nil {
+	if fee, err := forgeMutez(r.Fee); err == nil {
 		result.Write(fee)
 	} else {
 		return []byte{}, errors.Wrap(err, "failed to forge fee")
@@ -340,7 +378,7 @@ func forgeReveal(r rpc.Reveal) ([]byte, error) {
 }
 
 func forgeAccountActivation(a rpc.AccountActivation) ([]byte, error) {
-	err := validator.New().Struct(a)
+	err := validate.Struct(a)
 	if err != nil {
 		return []byte{}, errors.Wrap(err, "invalid input")
 	}
@@ -369,7 +407,7 @@ func forgeAccountActivation(a rpc.AccountActivation) ([]byte, error) {
 }
 
 func forgeTransaction(t rpc.Transaction) ([]byte, error) {
-	err := validator.New().Struct(t)
+	err := validate.Struct(t)
 	if err != nil {
 		return []byte{}, errors.Wrap(err, "invalid input")
 	}
@@ -388,7 +426,7 @@ func forgeTransaction(t rpc.Transaction) ([]byte, error) {
 		return []byte{}, errors.Wrap(err, "failed to forge source")
 	}
 
-	if fee, err := forgeNat(t.Fee); err == nil {
+	if fee, err := forgeMutez(t.Fee); err == nil {
 		result.Write(fee)
 	} else {
 		return []byte{}, errors.Wrap(err, "failed to forge fee")
@@ -412,7 +450,7 @@ func forgeTransaction(t rpc.Transaction) ([]byte, error) {
 		return []byte{}, errors.Wrap(err, "failed to forge storage_limit")
 	}
 
-	if amount, err := forgeNat(t.Amount); err == nil {
+	if amount, err := forgeMutez(t.Amount); err == nil {
 		result.Write(amount)
 	} else {
 		return []byte{}, errors.Wrap(err, "failed to forge amount")
@@ -448,7 +486,7 @@ func forgeTransaction(t rpc.Transaction) ([]byte, error) {
 }
 
 func forgeOrigination(o rpc.Origination) ([]byte, error) {
-	err := validator.New().Struct(o)
+	err := validate.Struct(o)
 	if err != nil {
 		return []byte{}, errors.Wrap(err, "invalid input")
 	}
@@ -467,7 +505,7 @@ func forgeOrigination(o rpc.Origination) ([]byte, error) {
 		return []byte{}, errors.Wrap(err, "failed to forge source")
 	}
 
-	if fee, err := forgeNat(o.Fee); err == nil {
+	if fee, err := forgeMutez(o.Fee); err == nil {
 		result.Write(fee)
 	} else {
 		return []byte{}, errors.Wrap(err, "failed to forge fee")
@@ -491,7 +529,7 @@ func forgeOrigination(o rpc.Origination) ([]byte, error) {
 		return []byte{}, errors.Wrap(err, "failed to forge storage_limit")
 	}
 
-	if balance, err := forgeNat(o.Balance); err == nil {
+	if balance, err := forgeMutez(o.Balance); err == nil {
 		result.Write(balance)
 	} else {
 		return []byte{}, errors.Wrap(err, "failed to forge balance")
@@ -518,7 +556,7 @@ func forgeOrigination(o rpc.Origination) ([]byte, error) {
 }
 
 func forgeDelegation(d rpc.Delegation) ([]byte, error) {
-	err := validator.New().Struct(d)
+	err := validate.Struct(d)
 	if err != nil {
 		return []byte{}, errors.Wrap(err, "invalid input")
 	}
@@ -537,7 +575,7 @@ func forgeDelegation(d rpc.Delegation) ([]byte, error) {
 		return []byte{}, errors.Wrap(err, "failed to forge source")
 	}
 
-	if fee, err := forgeNat(d.Fee); err == nil {
+	if fee, err := forgeMutez(d.Fee); err == nil {
 		result.Write(fee)
 	} else {
 		return []byte{}, errors.Wrap(err, "failed to forge fee")
@@ -576,7 +614,7 @@ func forgeDelegation(d rpc.Delegation) ([]byte, error) {
 }
 
 func forgeEndorsement(e rpc.Endorsement) ([]byte, error) {
-	err := validator.New().Struct(e)
+	err := validate.Struct(e)
 	if err != nil {
 		return []byte{}, errors.Wrap(err, "invalid input")
 	}
@@ -594,7 +632,7 @@ func forgeEndorsement(e rpc.Endorsement) ([]byte, error) {
 }
 
 func forgeSeedNonceRevelation(s rpc.SeedNonceRevelation) ([]byte, error) {
-	err := validator.New().Struct(s)
+	err := validate.Struct(s)
 	if err != nil {
 		return []byte{}, errors.Wrap(err, "invalid input")
 	}
@@ -619,14 +657,14 @@ func forgeSeedNonceRevelation(s rpc.SeedNonceRevelation) ([]byte, error) {
 }
 
 func forgeProposal(p rpc.Proposal) ([]byte, error) {
-	err := validator.New().Struct(p)
+	err := validate.Struct(p)
 	if err != nil {
 		return []byte{}, errors.Wrap(err, "invalid input")
 	}
 
 	result := bytes.NewBuffer([]byte{})
 
-	if kind, err := forgeNat(operationTags("proposal")); err == nil {
+	if kind, err := forgeNat(operationTags("proposals")); err == nil {
 		result.Write(kind)
 	} else {
 		return []byte{}, errors.Wrap(err, "failed to forge kind")
@@ -642,8 +680,8 @@ func forgeProposal(p rpc.Proposal) ([]byte, error) {
 
 	buf := bytes.NewBuffer([]byte{})
 	for _, proposal := range p.Proposals {
-		if p, err := prefixAndBase58Encode(proposal, proposalPrefix); err == nil {
-			buf.Write([]byte(p))
+		if p, err := forgeHash(proposal, proposalPrefix); err == nil {
+			buf.Write(p)
 		} else {
 			return []byte{}, errors.Wrap(err, "failed to forge proposals")
 		}
@@ -654,7 +692,7 @@ func forgeProposal(p rpc.Proposal) ([]byte, error) {
 }
 
 func forgeBallot(b rpc.Ballot) ([]byte, error) {
-	err := validator.New().Struct(b)
+	err := validate.Struct(b)
 	if err != nil {
 		return []byte{}, errors.Wrap(err, "invalid input")
 	}
@@ -675,19 +713,28 @@ func forgeBallot(b rpc.Ballot) ([]byte, error) {
 
 	result.Write(forgeInt32(b.Period, 4))
 
-	if p, err := prefixAndBase58Encode(b.Proposal, proposalPrefix); err == nil {
-		result.Write([]byte(p))
+	if p, err := forgeHash(b.Proposal, proposalPrefix); err == nil {
+		result.Write(p)
 	} else {
 		return []byte{}, errors.Wrap(err, "failed to forge proposal")
 	}
 
-	result.Write([]byte(b.Ballot))
+	switch b.Ballot {
+	case "yay":
+		result.WriteByte(0)
+	case "nay":
+		result.WriteByte(1)
+	case "pass":
+		result.WriteByte(2)
+	default:
+		return []byte{}, fmt.Errorf("failed to forge ballot: invalid ballot '%s'", b.Ballot)
+	}
 
 	return result.Bytes(), nil
 }
 
 func forgeDoubleEndorsementEvidence(d rpc.DoubleEndorsementEvidence) ([]byte, error) {
-	err := validator.New().Struct(d)
+	err := validate.Struct(d)
 	if err != nil {
 		return []byte{}, errors.Wrap(err, "invalid input")
 	}
@@ -716,7 +763,7 @@ func forgeDoubleEndorsementEvidence(d rpc.DoubleEndorsementEvidence) ([]byte, er
 }
 
 func forgeDoubleBakingEvidence(d rpc.DoubleBakingEvidence) ([]byte, error) {
-	err := validator.New().Struct(d)
+	err := validate.Struct(d)
 	if err != nil {
 		return []byte{}, errors.Wrap(err, "invalid input")
 	}
@@ -746,8 +793,8 @@ func forgeDoubleBakingEvidence(d rpc.DoubleBakingEvidence) ([]byte, error) {
 
 func forgeInlinedEndorsement(i rpc.InlinedEndorsement) ([]byte, error) {
 	result := bytes.NewBuffer([]byte{})
-	if branch, err := prefixAndBase58Encode(i.Branch, branchPrefix); err == nil {
-		result.Write([]byte(branch))
+	if branch, err := forgeHash(i.Branch, branchPrefix); err == nil {
+		result.Write(branch)
 	} else {
 		return []byte{}, errors.Wrap(err, "failed to forge branch")
 	}
@@ -760,8 +807,8 @@ func forgeInlinedEndorsement(i rpc.InlinedEndorsement) ([]byte, error) {
 
 	result.Write(forgeInt32(i.Operations.Level, 4))
 
-	if signature, err := prefixAndBase58Encode(i.Signature, sigPrefix); err == nil {
-		result.Write([]byte(signature))
+	if signature, err := forgeSignature(i.Signature); err == nil {
+		result.Write(signature)
 	} else {
 		return []byte{}, errors.Wrap(err, "failed to forge signature")
 	}
@@ -774,18 +821,17 @@ func forgeBlockHeader(b rpc.BlockHeader) ([]byte, error) {
 	result.Write(forgeInt32(b.Level, 4))
 	result.Write(forgeInt32(b.Proto, 1))
 
-	if predecessor, err := prefixAndBase58Encode(b.Predecessor, branchPrefix); err == nil {
-		result.Write([]byte(predecessor))
+	if predecessor, err := forgeHash(b.Predecessor, branchPrefix); err == nil {
+		result.Write(predecessor)
 	} else {
 		return []byte{}, errors.Wrap(err, "failed to forge predecessor")
 	}
 
-	ts := int(b.Timestamp.Sub(time.Date(1970, time.January, 1, 0, 0, 0, 0, nil)).Seconds())
-	result.Write(forgeInt32(ts, 8))
+	result.Write(forgeInt32(int(b.Timestamp.Unix()), 8))
 	result.Write(forgeInt32(b.ValidationPass, 1))
 
-	if operationHash, err := prefixAndBase58Encode(b.OperationsHash, operationPrefix); err == nil {
-		result.Write([]byte(operationHash))
+	if operationHash, err := forgeHash(b.OperationsHash, operationPrefix); err == nil {
+		result.Write(operationHash)
 	} else {
 		return []byte{}, errors.Wrap(err, "failed to forge operation_hash")
 	}
@@ -800,22 +846,34 @@ func forgeBlockHeader(b rpc.BlockHeader) ([]byte, error) {
 	}
 	result.Write(forgeArray(buf.Bytes(), 4))
 
-	if context, err := prefixAndBase58Encode(b.Context, contextPrefix); err == nil {
-		result.Write([]byte(context))
+	if context, err := forgeHash(b.Context, contextPrefix); err == nil {
+		result.Write(context)
 	} else {
 		return []byte{}, errors.Wrap(err, "failed to forge context")
 	}
 
 	result.Write(forgeInt32(b.Priority, 2))
 
-	if proofOfWorkNonce, err := hex.DecodeString(b.ProofOfWorkNonce); err == nil {
-		buf.Write(proofOfWorkNonce)
-	} else {
+	proofOfWorkNonce, err := hex.DecodeString(b.ProofOfWorkNonce)
+	if err != nil {
 		return []byte{}, errors.Wrap(err, "failed to forge proof_of_work_nonce")
 	}
+	if len(proofOfWorkNonce) != 8 {
+		return []byte{}, errors.New("failed to forge proof_of_work_nonce: invalid length")
+	}
+	result.Write(proofOfWorkNonce)
+
+	result.Write(forgeBool(b.SeedNonceHash != ""))
+	if b.SeedNonceHash != "" {
+		if seedNonceHash, err := forgeHash(b.SeedNonceHash, nonceHashPrefix); err == nil {
+			result.Write(seedNonceHash)
+		} else {
+			return []byte{}, errors.Wrap(err, "failed to forge seed_nonce_hash")
+		}
+	}
 
 	if signature, err := forgeSignature(b.Signature); err == nil {
-		buf.Write(signature)
+		result.Write(signature)
 	} else {
 		return []byte{}, errors.Wrap(err, "failed to forge signature")
 	}
@@ -823,42 +881,65 @@ func forgeBlockHeader(b rpc.BlockHeader) ([]byte, error) {
 	return forgeArray(result.Bytes(), 4), nil
 }
 
+// forgeSignature forges a curve specific or generic signature as its 64 bytes
 func forgeSignature(value string) ([]byte, error) {
-	var buf []byte
-	if signature, err := prefixAndBase58Encode(value, sigPrefix); err == nil {
-		buf = []byte(signature)
-	} else {
-		return []byte{}, errors.Wrap(err, "failed to forge context")
+	signature, err := tezos.ParseSignature(value)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	return signature.Bytes(), nil
+}
+
+// forgeHash forges a base58 encoded 32 byte hash (block, operations, context, ...) as its raw bytes
+func forgeHash(value string, prefix []byte) ([]byte, error) {
+	v, err := crypto.B58cdecode(value, prefix)
+	if err != nil {
+		return []byte{}, errors.Wrapf(err, "invalid hash '%s'", value)
+	}
+
+	if len(v) != 32 {
+		return []byte{}, fmt.Errorf("invalid hash '%s': invalid length", value)
 	}
 
-	return append([]byte{0}, buf...), nil
+	return v, nil
 }
 
+// forgeInt32 forges value as a big endian integer of l bytes
 func forgeInt32(value int, l int) []byte {
-	bigE := make([]byte, 4)
-	binary.BigEndian.PutUint32(bigE, uint32(value))
-	return bigE
+	bigE := make([]byte, 8)
+	binary.BigEndian.PutUint64(bigE, uint64(value))
+	return bigE[8-l:]
 }
 
 func forgeNat(value string) ([]byte, error) {
-	var z big.Int
-	_, ok := z.SetString(string(value), 10)
+	z, ok := new(big.Int).SetString(value, 10)
 	if !ok {
 		return nil, fmt.Errorf("value (%s) has to be a number", value)
 	}
-	val := int(z.Int64())
 
-	if val < 0 {
+	return forgeBigNat(z)
+}
+
+// forgeMutez forges an amount as a zarith encoded natural number
+func forgeMutez(value rpc.Mutez) ([]byte, error) {
+	return forgeBigNat(value.BigInt())
+}
+
+func forgeBigNat(value *big.Int) ([]byte, error) {
+	if value.Sign() < 0 {
 		return nil, fmt.Errorf("nat value (%s) cannot be negative", value)
 	}
 
+	val := new(big.Int).Set(value)
+	septet := big.NewInt(0x7f)
 	buf := bytes.NewBuffer([]byte{})
 	more := true
 
 	for more {
-		b := byte(val & 0x7f)
-		val >>= 7
-		if val > 0 {
+		b := byte(new(big.Int).And(val, septet).Uint64())
+		val.Rsh(val, 7)
+		if val.Sign() > 0 {
 			b |= 0x80
 		} else {
 			more = false
@@ -870,59 +951,67 @@ func forgeNat(value string) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// forgeBigInt forges an integer of any size in the signed zarith encoding of Micheline ints: the first byte holds
+// the sign and six bits, the following ones seven bits each
+func forgeBigInt(value *big.Int) []byte {
+	val := new(big.Int).Abs(value)
+
+	b := byte(new(big.Int).And(val, big.NewInt(0x3f)).Uint64())
+	if value.Sign() < 0 {
+		b |= 0x40
+	}
+	val.Rsh(val, 6)
+
+	v := []byte{b}
+	for val.Sign() > 0 {
+		v[len(v)-1] |= 0x80
+		v = append(v, byte(new(big.Int).And(val, big.NewInt(0x7f)).Uint64()))
+		val.Rsh(val, 7)
+	}
+
+	return v
+}
+
 func forgeSource(source string) ([]byte, error) {
-	var prefix string
-	if len(source) != 36 {
-		return []byte{}, fmt.Errorf("invalid length (%d!=36) source address", len(source))
+	keyHash, err := tezos.ParseKeyHash(source)
+	if err != nil {
+		return []byte{}, err
 	}
-	prefix = source[0:(0 + 3)]
 
-	buf, err := crypto.Decode(source)
+	return append([]byte{curveTag(keyHash.Curve())}, keyHash.Bytes()...), nil
+}
+
+func forgeAddress(address string) ([]byte, error) {
+	a, err := tezos.ParseAddress(address)
 	if err != nil {
-		return []byte{}, errors.Wrap(err, "failed to decode from base58")
+		return []byte{}, err
 	}
-	buf = buf[3:]
 
-	switch prefix {
-	case "tz1":
-		buf = append([]byte{0}, buf...)
-	case "tz2":
-		buf = append([]byte{1}, buf...)
-	case "tz3":
-		buf = append([]byte{2}, buf...)
-	default:
-		return []byte{}, fmt.Errorf("invalid source prefix '%s'", prefix)
+	if a.Entrypoint() != "" {
+		return []byte{}, fmt.Errorf("invalid address '%s': unexpected entrypoint", address)
 	}
 
-	return buf, nil
+	return forgeParsedAddress(a), nil
 }
 
-func forgeAddress(address string) ([]byte, error) {
-	if len(address) != 36 {
-		return []byte{}, fmt.Errorf("invalid length (%d!=36) source address", len(address))
+func forgeParsedAddress(a tezos.Address) []byte {
+	if a.IsOriginated() {
+		return append(append([]byte{1}, a.Hash()...), 0)
 	}
-	prefix := address[0:(0 + 3)]
-	buf, err := crypto.Decode(address)
-	if err != nil {
-		return []byte{}, errors.Wrap(err, "failed to decode from base58")
-	}
-	buf = buf[3:]
-
-	switch prefix {
-	case "tz1":
-		buf = append([]byte{0, 0}, buf...)
-	case "tz2":
-		buf = append([]byte{0, 1}, buf...)
-	case "tz3":
-		buf = append([]byte{0, 2}, buf...)
-	case "KT1":
-		buf = append([]byte{1}, buf...)
-		buf = append(buf, byte(0))
-	default:
-		return []byte{}, fmt.Errorf("invalid address prefix '%s'", prefix)
+
+	return append([]byte{0, curveTag(a.Curve())}, a.Hash()...)
+}
+
+// curveTag returns the tag of a curve in the binary encoding of key hashes and public keys
+func curveTag(curve tezos.ECKind) byte {
+	switch curve {
+	case tezos.Secp256k1:
+		return 1
+	case tezos.NistP256:
+		return 2
 	}
 
-	return buf, nil
+	return 0
 }
 
 func forgeBool(value bool) []byte {
@@ -965,82 +1054,23 @@ func forgeArray(value []byte, l int) []byte {
 	return bytes
 }
 
-func forgeInt(value int) []byte {
-	binary := strconv.FormatInt(int64(math.Abs(float64(value))), 2)
-	lenBin := len(binary)
-
-	pad := 6
-	if (lenBin-6)%7 == 0 {
-		pad = lenBin
-	} else if lenBin > 6 {
-		pad = lenBin + 7 - (lenBin-6)%7
-	}
-
-	binary = fmt.Sprintf("%0*s", pad, binary)
-	septets := []string{}
-
-	for i := 0; i <= pad/7; i++ {
-		index := 7 * i
-		length := int(math.Min(7, float64(pad-7*i)))
-		septets = append(septets, binary[index:(index+length)])
-	}
-
-	septets = reverseStrings(septets)
-	if value >= 0 {
-		septets[0] = fmt.Sprintf("0%s", septets[0])
-	} else {
-		septets[0] = fmt.Sprintf("1%s", septets[0])
-	}
-
-	buf := bytes.NewBuffer([]byte{})
-
-	for i := 0; i < len(septets); i++ {
-
-		prefix := "1"
-		if i == len(septets)-1 {
-			prefix = "0"
-		}
-		n := new(big.Int)
-		n.SetString(prefix+septets[i], 2)
-		buf.Write(n.Bytes())
-	}
-
-	return buf.Bytes()
-}
-
 func forgePublicKey(value string) ([]byte, error) {
-	if len(value) < 54 {
-		return []byte{}, fmt.Errorf("invalid public key '%s'", value)
-	}
-
-	prefix := value[0:(0 + 4)]
-	buf, err := crypto.Decode(value)
+	pk, err := tezos.ParsePublicKey(value)
 	if err != nil {
-		return []byte{}, errors.Wrap(err, "failed to decode from base58")
-	}
-	buf = buf[4:]
-
-	switch prefix {
-	case "edpk":
-		buf = append([]byte{0}, buf...)
-	case "sppk":
-		buf = append([]byte{1}, buf...)
-	case "p2pk":
-		buf = append([]byte{2}, buf...)
-	default:
-		return []byte{}, fmt.Errorf("invalid public key prefix '%s'", prefix)
+		return []byte{}, err
 	}
 
-	return buf, nil
+	return append([]byte{curveTag(pk.Curve())}, pk.Bytes()...), nil
 }
 
+// forgeActivationAddress forges the tz1 address of an account activation as its 20 bytes hash
 func forgeActivationAddress(value string) ([]byte, error) {
-	buf := base58.Decode(value)
-	if len(buf) < 3 {
+	buf, err := crypto.B58cdecode(value, tz1Prefix)
+	if err != nil || len(buf) != 20 {
 		return []byte{}, fmt.Errorf("invalid activation address '%s'", value)
 	}
 
-	return buf[3:], nil
+	return buf, nil
 }
 
 func forgeScript(script rpc.Script) ([]byte, error) {
@@ -1141,8 +1171,13 @@ func forgeMicheline(micheline *fastjson.Value) ([]byte, error) {
 			}
 			annotsLen := len(annots) // NOT SURE IF CORRECT WAY TO USE PARSER
 
+			tag, err := primTags(strings.Trim(obj.Get("prim").String(), "\""))
+			if err != nil {
+				return []byte{}, err
+			}
+
 			buf.WriteByte(lenTags[argsLen][annotsLen > 0])
-			buf.WriteByte(primTags(strings.Trim(obj.Get("prim").String(), "\"")))
+			buf.WriteByte(tag)
 
 			if argsLen > 0 {
 				argsBuf := bytes.NewBuffer([]byte{})
@@ -1180,12 +1215,12 @@ func forgeMicheline(micheline *fastjson.Value) ([]byte, error) {
 		} else if obj.Get("int") != nil {
 			buf.WriteByte(0x00)
 
-			i, err := strconv.Atoi(strings.Trim(obj.Get("int").String(), "\""))
-			if err != nil {
+			i, ok := new(big.Int).SetString(strings.Trim(obj.Get("int").String(), "\""), 10)
+			if !ok {
 				return []byte{}, errors.New("failed to forge \"int\"")
 			}
 
-			buf.Write(forgeInt(i))
+			buf.Write(forgeBigInt(i))
 		} else if obj.Get("string") != nil {
 			buf.WriteByte(0x01)
 			buf.Write(forgeArray(bytes.Trim(obj.Get("string").MarshalTo([]byte{}), "\""), 4))
@@ -1195,17 +1230,53 @@ func forgeMicheline(micheline *fastjson.Value) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-func prefixAndBase58Encode(hexPayload string, prefix []byte) (string, error) {
-	v, err := hex.DecodeString(fmt.Sprintf("%s%s", hex.EncodeToString(prefix), hexPayload))
+/*
+OperationHash computes the hash of a signed operation locally, which is the hash the node returns when it is
+injected. This allows recording the hash of an operation before injecting it. The operation is decoded (see
+Decode) and an error is returned if it is not signed, since the hash of an unsigned operation is not the hash of
+any operation the node accepts.
+
+Parameters:
+
+	signed:
+		The hex encoded forged operation followed by its signature (see keys.Signature.AppendToHex).
+
+Example:
+	op, err := forge.Encode(branch, contents...)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to encode to base58")
+		return err
 	}
-	return crypto.Encode(v), nil
+
+	signature, err := key.SignHex(op)
+	if err != nil {
+		return err
+	}
+
+	hash, err := forge.OperationHash(signature.AppendToHex(op))
+*/
+func OperationHash(signed string) (string, error) {
+	signed = strings.TrimPrefix(signed, "0x")
+	_, _, signature, err := Decode(signed)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to hash operation")
+	}
+
+	if signature == "" {
+		return "", errors.New("failed to hash operation: operation is not signed")
+	}
+
+	v, err := hex.DecodeString(signed)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to hash operation: invalid hex")
+	}
+
+	hash := blake2b.Sum256(v)
+	return crypto.B58cencode(hash[:], operationHashPrefix), nil
 }
 
 // IntExpression will pack and encode an integer to a script_expr
 func IntExpression(i int) (string, error) {
-	v, err := blakeHash(fmt.Sprintf("0500%s", hex.EncodeToString(forgeInt(i))))
+	v, err := blakeHash(fmt.Sprintf("0500%s", hex.EncodeToString(forgeBigInt(big.NewInt(int64(i))))))
 	if err != nil {
 		return "", errors.Wrap(err, "failed to pack int")
 	}
@@ -3,6 +3,7 @@ package forge
 import (
 	"encoding/hex"
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/goat-systems/go-tezos/v4/internal/testutils"
@@ -530,6 +531,10 @@ func Test_Forge_Origination(t *testing.T) {
 	err = json.Unmarshal(originationJSON2, &origination2)
 	testutils.CheckErr(t, false, "", err)
 
+	unknownPrim := json.RawMessage(`[{"prim":"parameter","args":[{"prim":"unit"}]},{"prim":"storage","args":[{"prim":"unit"}]},{"prim":"code","args":[[{"prim":"SET_CAR"}]]}]`)
+	origination3 := origination
+	origination3.Script.Code = &unknownPrim
+
 	type want struct {
 		err         bool
 		errContains string
@@ -559,6 +564,15 @@ func Test_Forge_Origination(t *testing.T) {
 				"6d0054013ef6636fe99989a26006622bf270be0b14859610d3de12af7c8e040000000000ef02000000ea0500036c0501036c050202000000db0321051f0200000002031703160743036e01000000244b54314d384d5374774131523553754778325636414d7667643864474146596345556d7505550764085e036c055f036d0000000325646f046c000000082564656661756c74072f020000001807430368010000000d74797065206d69736d6174636803270200000051020000000f071f00020200000002032105700003053d036d020000000f071f00020200000002032105700003071f0003020000000203200743036a0080897a034f0544075e036c055f036d034d031b0342051f02000000040320032000000002030b",
 			},
 		},
+		{
+			"handles unknown primitive",
+			origination3,
+			want{
+				true,
+				"unknown primitive 'SET_CAR'",
+				"",
+			},
+		},
 	}
 
 	for _, tt := range cases {
@@ -747,6 +761,28 @@ func Test_Forge_Reveal(t *testing.T) {
 	}
 }
 
+func Test_Forge_Fee(t *testing.T) {
+	source := "tz1f2MeahW6XMLcfHJSU5VH8USC4EuFiwdhx"
+	code, storage := json.RawMessage(`[]`), json.RawMessage(`{"int":"0"}`)
+	contents := []rpc.Content{
+		{Kind: rpc.REVEAL, Source: source, Counter: "5", GasLimit: "10000", StorageLimit: "0", PublicKey: "edpkuEmaQSYKgDj5k9wfE3bTxjfjoG9k5YvRmYZsGf2bjEymZKkzNn"},
+		{Kind: rpc.TRANSACTION, Source: source, Counter: "5", GasLimit: "10307", StorageLimit: "0", Amount: rpc.NewMutez(1), Destination: "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc"},
+		{Kind: rpc.ORIGINATION, Source: source, Counter: "5", GasLimit: "10307", StorageLimit: "257", Script: rpc.Script{Code: &code, Storage: &storage}},
+		{Kind: rpc.DELEGATION, Source: source, Counter: "5", GasLimit: "10307", StorageLimit: "0", Delegate: "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc"},
+	}
+
+	for _, content := range contents {
+		t.Run(string(content.Kind), func(t *testing.T) {
+			_, err := Encode("", content)
+			testutils.CheckErr(t, true, "'Fee' failed on the 'required' tag", err)
+
+			content.Fee = rpc.NewMutez(0)
+			_, err = Encode("", content)
+			testutils.CheckErr(t, false, "", err)
+		})
+	}
+}
+
 func Test_IntExpression(t *testing.T) {
 	val, err := IntExpression(9)
 	testutils.CheckErr(t, false, "", err)
@@ -755,6 +791,12 @@ func Test_IntExpression(t *testing.T) {
 	val, err = IntExpression(-9)
 	testutils.CheckErr(t, false, "", err)
 	assert.Equal(t, "exprvH9jru3NJN4ZTNwwkCdC1PPLkWLWCoe6JxhcJ3a39mD5Bd4NH4", val)
+
+	val, err = IntExpression(0)
+	testutils.CheckErr(t, false, "", err)
+	want, err := PackExpression(`{"int":"0"}`, `{"prim":"int"}`)
+	testutils.CheckErr(t, false, "", err)
+	assert.Equal(t, want, val)
 }
 
 func Test_NatExpression(t *testing.T) {
@@ -794,3 +836,29 @@ func Test_MichelineExpression(t *testing.T) {
 	testutils.CheckErr(t, false, "", err)
 	assert.Equal(t, "exprupozG51AtT7yZUy5sg6VbJQ4b9omAE1PKD2PXvqi2YBuZqoKG3", val)
 }
+
+func Test_OperationHash(t *testing.T) {
+	op := "5aff622d53d32a8bae591627718c60a35b16737e301c57a13b6f1765483d88ff6c007fd82c06cf5a203f18faaf562447ed1efcc6c010830a07c350008090dfc04a0000a31e81ac3425310e3274a4698a793b2839dc0afa00"
+	signature := strings.Repeat("ab", 64)
+
+	val, err := OperationHash(op + signature)
+	testutils.CheckErr(t, false, "", err)
+	assert.Equal(t, "ooe9dux5f4e4FroaRUBxuehuVHsL9KLA2fEnaiYdqbax3nxkaqz", val)
+
+	val, err = OperationHash("0x" + op + signature)
+	testutils.CheckErr(t, false, "", err)
+	assert.Equal(t, "ooe9dux5f4e4FroaRUBxuehuVHsL9KLA2fEnaiYdqbax3nxkaqz", val)
+
+	_, err = OperationHash(op)
+	testutils.CheckErr(t, true, "operation is not signed", err)
+
+	// two transactions are longer than a signature, so only decoding tells the operation is unsigned
+	_, err = OperationHash(op + op[64:])
+	testutils.CheckErr(t, true, "operation is not signed", err)
+
+	_, err = OperationHash(op + op[64:] + signature)
+	testutils.CheckErr(t, false, "", err)
+
+	_, err = OperationHash(op + signature + "a")
+	testutils.CheckErr(t, true, "invalid hex", err)
+}
@@ -0,0 +1,322 @@
+package forge
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/goat-systems/go-tezos/v4/internal/crypto"
+	"github.com/goat-systems/go-tezos/v4/tezos"
+	"github.com/pkg/errors"
+)
+
+/*
+Pack packs Micheline data of a type locally, producing the same bytes as the PACK instruction and the node's
+pack_data RPC. Values are packed in their optimized form: addresses, contracts, key hashes, keys, signatures and
+chain ids in binary, timestamps as ints, and pairs as nested Pair. It is the reverse of UnpackWithType.
+
+Parameters:
+
+	value:
+		The Micheline JSON data to pack (e.g. {"prim":"Pair","args":[{"string":"tz1L8fUQLuwRuywTZUP5JUw9LL3kJa8LMfoo"},{"int":"1"}]}).
+
+	typ:
+		The Micheline JSON type of the data (e.g. {"prim":"pair","args":[{"prim":"address"},{"prim":"nat"}]}).
+
+Example:
+	v, err := forge.Pack(`{"string":"tz1L8fUQLuwRuywTZUP5JUw9LL3kJa8LMfoo"}`, `{"prim":"address"}`) // 050a000000160000056a59972593bdc74a5295671c8f5d43c21348da
+*/
+func Pack(value string, typ string) (string, error) {
+	v, err := pack(value, typ)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(v), nil
+}
+
+/*
+PackExpression packs Micheline data of a type like Pack and encodes its hash to a script_expr, which is how big
+map keys are looked up.
+
+Parameters:
+
+	value:
+		The Micheline JSON data to pack.
+
+	typ:
+		The Micheline JSON type of the data.
+
+Example:
+	key, err := forge.PackExpression(`{"prim":"Pair","args":[{"string":"tz1L8fUQLuwRuywTZUP5JUw9LL3kJa8LMfoo"},{"int":"1"}]}`, `{"prim":"pair","args":[{"prim":"address"},{"prim":"nat"}]}`)
+	if err != nil {
+		return err
+	}
+
+	value, err := client.BigMap(rpc.BigMapInput{
+		BlockID:          &rpc.BlockIDHead{},
+		BigMapID:         1,
+		ScriptExpression: key,
+	})
+*/
+func PackExpression(value string, typ string) (string, error) {
+	v, err := pack(value, typ)
+	if err != nil {
+		return "", err
+	}
+
+	hash, err := blakeHash(hex.EncodeToString(v))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to pack")
+	}
+
+	return crypto.B58cencode(hash, scriptExpressionPrefix), nil
+}
+
+func pack(value string, typ string) ([]byte, error) {
+	var v, t michelineNode
+	if err := json.Unmarshal([]byte(value), &v); err != nil {
+		return nil, errors.Wrap(err, "failed to pack: invalid value")
+	}
+
+	if err := json.Unmarshal([]byte(typ), &t); err != nil {
+		return nil, errors.Wrap(err, "failed to pack: invalid type")
+	}
+
+	v, err := optimized(v, t)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to pack")
+	}
+
+	buf := bytes.NewBuffer([]byte{0x05})
+	if err := packMicheline(v, buf); err != nil {
+		return nil, errors.Wrap(err, "failed to pack")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// optimized returns value with the domain values typ describes converted to their optimized form
+func optimized(value, typ michelineNode) (michelineNode, error) {
+	switch typ.Prim {
+	case "address", "contract":
+		return optimizedString(value, typ.Prim, func(s string) ([]byte, error) {
+			a, err := tezos.ParseAddress(s)
+			if err != nil {
+				return nil, err
+			}
+
+			v := forgeParsedAddress(a)
+			if a.Entrypoint() != "default" {
+				v = append(v, a.Entrypoint()...)
+			}
+			return v, nil
+		})
+	case "key_hash":
+		return optimizedString(value, typ.Prim, forgeSource)
+	case "key":
+		return optimizedString(value, typ.Prim, forgePublicKey)
+	case "signature":
+		return optimizedString(value, typ.Prim, forgeSignature)
+	case "chain_id":
+		return optimizedString(value, typ.Prim, func(s string) ([]byte, error) {
+			id, err := tezos.ParseChainID(s)
+			if err != nil {
+				return nil, err
+			}
+			return id[:], nil
+		})
+	case "timestamp":
+		if value.String == nil {
+			return value, nil
+		}
+
+		timestamp, err := time.Parse(time.RFC3339, *value.String)
+		if err != nil {
+			return value, errors.Wrap(err, "invalid timestamp")
+		}
+
+		seconds := big.NewInt(timestamp.Unix()).String()
+		return michelineNode{Int: &seconds}, nil
+	case "pair":
+		return optimizedPair(value, typ.Args)
+	case "ticket":
+		return value, errors.New("tickets cannot be packed")
+	case "option":
+		if value.Prim != "Some" {
+			return value, nil
+		}
+		return optimizedArgs(value, typ.Args)
+	case "or":
+		if len(typ.Args) != 2 || len(value.Args) != 1 {
+			return value, errors.New("invalid or value for type")
+		}
+
+		switch value.Prim {
+		case "Left":
+			return optimizedArgs(value, typ.Args[:1])
+		case "Right":
+			return optimizedArgs(value, typ.Args[1:])
+		}
+	case "list", "set":
+		if !value.IsSeq || len(typ.Args) != 1 {
+			return value, nil
+		}
+
+		for i := range value.Seq {
+			v, err := optimized(value.Seq[i], typ.Args[0])
+			if err != nil {
+				return value, err
+			}
+			value.Seq[i] = v
+		}
+	case "map", "big_map":
+		if !value.IsSeq || len(typ.Args) != 2 {
+			return value, nil
+		}
+
+		for i := range value.Seq {
+			v, err := optimizedArgs(value.Seq[i], typ.Args)
+			if err != nil {
+				return value, err
+			}
+			value.Seq[i] = v
+		}
+	}
+
+	return value, nil
+}
+
+func optimizedString(value michelineNode, prim string, forge func(s string) ([]byte, error)) (michelineNode, error) {
+	if value.String == nil {
+		return value, nil
+	}
+
+	v, err := forge(*value.String)
+	if err != nil {
+		return value, errors.Wrapf(err, "invalid %s '%s'", prim, *value.String)
+	}
+
+	b := hex.EncodeToString(v)
+	return michelineNode{Bytes: &b}, nil
+}
+
+// optimizedArgs converts the args of value with types, which must be as many
+func optimizedArgs(value michelineNode, types []michelineNode) (michelineNode, error) {
+	if len(value.Args) != len(types) {
+		return value, errors.Errorf("expected %d args for %s but got %d", len(types), value.Prim, len(value.Args))
+	}
+
+	for i := range value.Args {
+		v, err := optimized(value.Args[i], types[i])
+		if err != nil {
+			return value, err
+		}
+		value.Args[i] = v
+	}
+
+	return value, nil
+}
+
+/*
+optimizedPair converts a pair, which is either a Pair or a sequence of its right combed values, to a Pair of two
+values, since the node packs combs as nested pairs. Types and values can be combed differently, so both are
+uncombed one level at a time.
+*/
+func optimizedPair(value michelineNode, types []michelineNode) (michelineNode, error) {
+	values := value.Args
+	if value.IsSeq {
+		values = value.Seq
+	} else if value.Prim != "Pair" {
+		return value, errors.Errorf("expected Pair but got %s", value.Prim)
+	}
+
+	if len(values) < 2 || len(types) < 2 {
+		return value, errors.New("pair does not match its type")
+	}
+
+	if len(values) > 2 {
+		values = []michelineNode{values[0], {Prim: "Pair", Args: values[1:]}}
+	}
+
+	if len(types) > 2 {
+		types = []michelineNode{types[0], {Prim: "pair", Args: types[1:]}}
+	}
+
+	return optimizedArgs(michelineNode{Prim: "Pair", Args: values}, types)
+}
+
+// packMicheline writes the binary encoding of a Micheline expression to buf
+func packMicheline(n michelineNode, buf *bytes.Buffer) error {
+	switch {
+	case n.IsSeq:
+		items := bytes.NewBuffer([]byte{})
+		for _, item := range n.Seq {
+			if err := packMicheline(item, items); err != nil {
+				return err
+			}
+		}
+
+		buf.WriteByte(0x02)
+		buf.Write(forgeArray(items.Bytes(), 4))
+	case n.Int != nil:
+		v, ok := new(big.Int).SetString(*n.Int, 10)
+		if !ok {
+			return errors.Errorf("invalid int '%s'", *n.Int)
+		}
+
+		buf.WriteByte(0x00)
+		buf.Write(forgeBigInt(v))
+	case n.String != nil:
+		buf.WriteByte(0x01)
+		buf.Write(forgeArray([]byte(*n.String), 4))
+	case n.Bytes != nil:
+		v, err := hex.DecodeString(*n.Bytes)
+		if err != nil {
+			return errors.Wrapf(err, "invalid bytes '%s'", *n.Bytes)
+		}
+
+		buf.WriteByte(0x0A)
+		buf.Write(forgeArray(v, 4))
+	default:
+		tag, ok := primitives[n.Prim]
+		if !ok {
+			return errors.Errorf("unknown primitive '%s'", n.Prim)
+		}
+
+		annots := len(n.Annots) > 0
+		switch len(n.Args) {
+		case 0:
+			buf.WriteByte(map[bool]byte{false: 0x03, true: 0x04}[annots])
+		case 1:
+			buf.WriteByte(map[bool]byte{false: 0x05, true: 0x06}[annots])
+		case 2:
+			buf.WriteByte(map[bool]byte{false: 0x07, true: 0x08}[annots])
+		default:
+			buf.WriteByte(0x09)
+		}
+		buf.WriteByte(tag)
+
+		args := bytes.NewBuffer([]byte{})
+		for _, arg := range n.Args {
+			if err := packMicheline(arg, args); err != nil {
+				return err
+			}
+		}
+
+		if len(n.Args) > 2 {
+			buf.Write(forgeArray(args.Bytes(), 4))
+		} else {
+			buf.Write(args.Bytes())
+		}
+
+		if annots || len(n.Args) > 2 {
+			buf.Write(forgeArray([]byte(strings.Join(n.Annots, " ")), 4))
+		}
+	}
+
+	return nil
+}
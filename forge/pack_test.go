@@ -0,0 +1,220 @@
+package forge
+
+import (
+	"testing"
+
+	"github.com/goat-systems/go-tezos/v4/internal/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Pack(t *testing.T) {
+	type input struct {
+		value string
+		typ   string
+	}
+
+	cases := []struct {
+		name        string
+		input       input
+		wantErr     bool
+		containsErr string
+		want        string
+	}{
+		{
+			"is successful with zero",
+			input{`{"int":"0"}`, `{"prim":"int"}`},
+			false,
+			"",
+			"050000",
+		},
+		{
+			"is successful with mutez",
+			input{`{"int":"1000000"}`, `{"prim":"mutez"}`},
+			false,
+			"",
+			"050080897a",
+		},
+		{
+			"is successful with nat larger than 64 bits",
+			input{`{"int":"18446744073709551616"}`, `{"prim":"nat"}`},
+			false,
+			"",
+			"050080808080808080808004",
+		},
+		{
+			"is successful with escaped string",
+			input{`{"string":"a\"b\n"}`, `{"prim":"string"}`},
+			false,
+			"",
+			"0501000000046122620a",
+		},
+		{
+			"is successful with address",
+			input{`{"string":"tz1L8fUQLuwRuywTZUP5JUw9LL3kJa8LMfoo"}`, `{"prim":"address"}`},
+			false,
+			"",
+			"050a000000160000056a59972593bdc74a5295671c8f5d43c21348da",
+		},
+		{
+			"is successful with address and entrypoint",
+			input{`{"string":"tz1L8fUQLuwRuywTZUP5JUw9LL3kJa8LMfoo%transfer"}`, `{"prim":"address"}`},
+			false,
+			"",
+			"050a0000001e0000056a59972593bdc74a5295671c8f5d43c21348da7472616e73666572",
+		},
+		{
+			"is successful with address and default entrypoint",
+			input{`{"string":"tz1L8fUQLuwRuywTZUP5JUw9LL3kJa8LMfoo%default"}`, `{"prim":"contract","args":[{"prim":"unit"}]}`},
+			false,
+			"",
+			"050a000000160000056a59972593bdc74a5295671c8f5d43c21348da",
+		},
+		{
+			"is successful with timestamp",
+			input{`{"string":"2020-09-13T12:26:40Z"}`, `{"prim":"timestamp"}`},
+			false,
+			"",
+			"050080c0f0f50b",
+		},
+		{
+			"is successful with pair",
+			input{`{"prim":"Pair","args":[{"string":"tz1L8fUQLuwRuywTZUP5JUw9LL3kJa8LMfoo"},{"int":"1"}]}`, `{"prim":"pair","args":[{"prim":"address"},{"prim":"nat"}]}`},
+			false,
+			"",
+			"0507070a000000160000056a59972593bdc74a5295671c8f5d43c21348da0001",
+		},
+		{
+			"is successful with comb pair",
+			input{`{"prim":"Pair","args":[{"int":"1"},{"int":"2"},{"int":"3"}]}`, `{"prim":"pair","args":[{"prim":"int"},{"prim":"pair","args":[{"prim":"int"},{"prim":"int"}]}]}`},
+			false,
+			"",
+			"0507070001070700020003",
+		},
+		{
+			"is successful with comb pair sequence",
+			input{`[{"int":"1"},{"int":"2"},{"int":"3"}]`, `{"prim":"pair","args":[{"prim":"int"},{"prim":"int"},{"prim":"int"}]}`},
+			false,
+			"",
+			"0507070001070700020003",
+		},
+		{
+			"is successful with option",
+			input{`{"prim":"Some","args":[{"string":"tz1L8fUQLuwRuywTZUP5JUw9LL3kJa8LMfoo"}]}`, `{"prim":"option","args":[{"prim":"key_hash"}]}`},
+			false,
+			"",
+			"0505090a0000001500056a59972593bdc74a5295671c8f5d43c21348da",
+		},
+		{
+			"is successful with or",
+			input{`{"prim":"Left","args":[{"prim":"Unit"}]}`, `{"prim":"or","args":[{"prim":"unit"},{"prim":"nat"}]}`},
+			false,
+			"",
+			"050505030b",
+		},
+		{
+			"is successful with map",
+			input{`[{"prim":"Elt","args":[{"string":"tz1L8fUQLuwRuywTZUP5JUw9LL3kJa8LMfoo"},{"prim":"True"}]}]`, `{"prim":"map","args":[{"prim":"key_hash"},{"prim":"bool"}]}`},
+			false,
+			"",
+			"05020000001e07040a0000001500056a59972593bdc74a5295671c8f5d43c21348da030a",
+		},
+		{
+			"handles invalid address",
+			input{`{"string":"tz1tacos"}`, `{"prim":"address"}`},
+			true,
+			"invalid address 'tz1tacos'",
+			"",
+		},
+		{
+			"handles signature with another prefix",
+			input{`{"string":"edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav"}`, `{"prim":"signature"}`},
+			true,
+			"invalid signature 'edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav'",
+			"",
+		},
+		{
+			"handles chain id with another prefix",
+			input{`{"string":"BLQMkH2PSTuAJgVm6rGHshY5z6Z6SAmqXv6q1LDzhX6fchJ12Up"}`, `{"prim":"chain_id"}`},
+			true,
+			"invalid chain_id 'BLQMkH2PSTuAJgVm6rGHshY5z6Z6SAmqXv6q1LDzhX6fchJ12Up'",
+			"",
+		},
+		{
+			"handles pair that does not match its type",
+			input{`{"prim":"Pair","args":[{"int":"1"},{"int":"2"}]}`, `{"prim":"pair","args":[{"prim":"int"},{"prim":"pair","args":[{"prim":"int"},{"prim":"int"}]}]}`},
+			true,
+			"expected Pair but got",
+			"",
+		},
+		{
+			"handles ticket",
+			input{`{"int":"1"}`, `{"prim":"ticket","args":[{"prim":"nat"}]}`},
+			true,
+			"tickets cannot be packed",
+			"",
+		},
+		{
+			"handles unknown primitive",
+			input{`{"prim":"Tacos"}`, `{"prim":"unit"}`},
+			true,
+			"unknown primitive 'Tacos'",
+			"",
+		},
+		{
+			"handles invalid type",
+			input{`{"int":"1"}`, `{"prim":`},
+			true,
+			"failed to pack: invalid type",
+			"",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := Pack(tt.input.value, tt.input.typ)
+			testutils.CheckErr(t, tt.wantErr, tt.containsErr, err)
+			assert.Equal(t, tt.want, v)
+		})
+	}
+}
+
+func Test_PackExpression(t *testing.T) {
+	cases := []struct {
+		value string
+		typ   string
+		want  string
+	}{
+		{`{"int":"-9"}`, `{"prim":"int"}`, "exprvH9jru3NJN4ZTNwwkCdC1PPLkWLWCoe6JxhcJ3a39mD5Bd4NH4"},
+		{`{"string":"Tezos Tacos Nachos"}`, `{"prim":"string"}`, "expruGmscHLuUazE7d79EepWCnDuPJreo8R87wsDGUgKAuH4E5ayEj"},
+		{`{"string":"tz1eEnQhbwf6trb8Q8mPb2RaPkNk2rN7BKi8"}`, `{"prim":"key_hash"}`, "expruqnFVtyPKd2KcrjkiJTaqE1WU1fEf8K1ajHvzgKz5pcc5sZyjn"},
+		{`{"prim":"Pair","args":[{"int":"1"},{"int":"12"}]}`, `{"prim":"pair","args":[{"prim":"int"},{"prim":"int"}]}`, "exprupozG51AtT7yZUy5sg6VbJQ4b9omAE1PKD2PXvqi2YBuZqoKG3"},
+	}
+
+	for _, tt := range cases {
+		v, err := PackExpression(tt.value, tt.typ)
+		testutils.CheckErr(t, false, "", err)
+		assert.Equal(t, tt.want, v)
+	}
+}
+
+func Test_Pack_RoundTrip(t *testing.T) {
+	typ := `{"prim":"pair","args":[{"prim":"address"},{"prim":"key"},{"prim":"chain_id"},{"prim":"timestamp"},{"prim":"list","args":[{"prim":"signature"}]}]}`
+	value := `{"prim":"Pair","args":[` +
+		`{"string":"KT1Njyz94x2pNJGh5uMhKj24VB9JsGCdkySN%transfer"},` +
+		`{"string":"edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav"},` +
+		`{"string":"NetXdQprcVkpaWU"},` +
+		`{"string":"2020-09-13T12:26:40Z"},` +
+		`[{"string":"sigXeXB5JD5TaLb3xgTPKjgf9W45judiCmNP9UBdZBdmtHSGBxL1M8ZSUb6LpjGP2MdfUBTB4WHs5APnvyRV1LooU6QHJuDe"}]]}`
+
+	packed, err := Pack(value, typ)
+	testutils.CheckErr(t, false, "", err)
+
+	v, err := UnpackWithType(packed, typ)
+	testutils.CheckErr(t, false, "", err)
+	assert.JSONEq(t, `{"prim":"Pair","args":[`+
+		`{"string":"KT1Njyz94x2pNJGh5uMhKj24VB9JsGCdkySN%transfer"},{"prim":"Pair","args":[`+
+		`{"string":"edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav"},{"prim":"Pair","args":[`+
+		`{"string":"NetXdQprcVkpaWU"},{"prim":"Pair","args":[`+
+		`{"string":"2020-09-13T12:26:40Z"},`+
+		`[{"string":"sigXeXB5JD5TaLb3xgTPKjgf9W45judiCmNP9UBdZBdmtHSGBxL1M8ZSUb6LpjGP2MdfUBTB4WHs5APnvyRV1LooU6QHJuDe"}]]}]}]}]}`, string(v))
+}
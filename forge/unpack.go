@@ -0,0 +1,305 @@
+package forge
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goat-systems/go-tezos/v4/internal/crypto"
+	"github.com/goat-systems/go-tezos/v4/tezos"
+	"github.com/pkg/errors"
+)
+
+/*
+Unpack decodes packed Micheline data, as produced by the PACK instruction and the node's pack_data RPC, back into
+its Micheline JSON form. It is the reverse of the encoding MichelineExpression hashes. Addresses, keys and other
+domain values come back in their optimized (bytes) form, see UnpackWithType to read them in base58.
+
+Parameters:
+
+	packed:
+		The hex encoded packed data, starting with the 05 prefix.
+
+Example:
+	v, err := forge.Unpack("0501000000057461636f73") // {"string":"tacos"}
+*/
+func Unpack(packed string) (json.RawMessage, error) {
+	v, err := hex.DecodeString(strings.TrimPrefix(packed, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unpack: invalid hex")
+	}
+
+	if len(v) == 0 || v[0] != 0x05 {
+		return nil, errors.New("failed to unpack: packed data must start with 05")
+	}
+
+	r := &reader{buf: v[1:]}
+	buf := bytes.NewBuffer([]byte{})
+	if err := unforgeMicheline(r, buf); err != nil {
+		return nil, errors.Wrap(err, "failed to unpack")
+	}
+
+	if r.remaining() != 0 {
+		return nil, errors.New("failed to unpack: unexpected trailing bytes in micheline")
+	}
+
+	return json.RawMessage(buf.Bytes()), nil
+}
+
+/*
+UnpackWithType decodes packed Micheline data like Unpack, and uses typ, the Micheline JSON type of the data, to
+return values in their readable form: addresses, contracts, key hashes, keys, signatures and chain ids in base58,
+and timestamps as RFC3339 strings.
+
+Parameters:
+
+	packed:
+		The hex encoded packed data, starting with the 05 prefix.
+
+	typ:
+		The Micheline JSON type of the data (e.g. {"prim":"pair","args":[{"prim":"address"},{"prim":"nat"}]}).
+
+Example:
+	v, err := forge.UnpackWithType("050a000000160000056a59972593bdc74a5295671c8f5d43c21348da", `{"prim":"address"}`) // {"string":"tz1L8fUQLuwRuywTZUP5JUw9LL3kJa8LMfoo"}
+*/
+func UnpackWithType(packed string, typ string) (json.RawMessage, error) {
+	v, err := Unpack(packed)
+	if err != nil {
+		return nil, err
+	}
+
+	var value, t michelineNode
+	if err := json.Unmarshal(v, &value); err != nil {
+		return nil, errors.Wrap(err, "failed to unpack")
+	}
+
+	if err := json.Unmarshal([]byte(typ), &t); err != nil {
+		return nil, errors.Wrap(err, "failed to unpack: invalid type")
+	}
+
+	value, err = readable(value, t)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unpack")
+	}
+
+	return json.Marshal(value)
+}
+
+// readable returns value with the domain values typ describes converted to their readable form
+func readable(value, typ michelineNode) (michelineNode, error) {
+	switch typ.Prim {
+	case "address", "contract":
+		return readableBytes(value, typ.Prim, func(r *reader) (string, error) {
+			address, err := unforgeAddress(r)
+			if err != nil {
+				return "", err
+			}
+
+			if r.remaining() > 0 {
+				entrypoint, _ := r.next(r.remaining())
+				address += "%" + string(entrypoint)
+			}
+			return address, nil
+		})
+	case "key_hash":
+		return readableBytes(value, typ.Prim, unforgeSource)
+	case "key":
+		return readableBytes(value, typ.Prim, unforgePublicKey)
+	case "signature":
+		return readableBytes(value, typ.Prim, func(r *reader) (string, error) {
+			v, err := r.next(signatureByteLength)
+			if err != nil {
+				return "", err
+			}
+			return crypto.B58cencode(v, sigPrefix), nil
+		})
+	case "chain_id":
+		return readableBytes(value, typ.Prim, func(r *reader) (string, error) {
+			v, err := r.next(4)
+			if err != nil {
+				return "", err
+			}
+
+			var id tezos.ChainID
+			copy(id[:], v)
+			return id.String(), nil
+		})
+	case "timestamp":
+		if value.Int == nil {
+			return value, nil
+		}
+
+		seconds, err := strconv.ParseInt(*value.Int, 10, 64)
+		if err != nil {
+			// timestamps outside of int64 have no readable form
+			return value, nil
+		}
+
+		timestamp := time.Unix(seconds, 0).UTC().Format(time.RFC3339)
+		return michelineNode{String: &timestamp}, nil
+	case "pair":
+		return readablePair(value, typ.Args)
+	case "ticket":
+		if len(typ.Args) != 1 {
+			return value, errors.New("invalid ticket type")
+		}
+		return readablePair(value, []michelineNode{{Prim: "address"}, typ.Args[0], {Prim: "nat"}})
+	case "option":
+		if value.Prim != "Some" {
+			return value, nil
+		}
+		return readableArgs(value, typ.Args)
+	case "or":
+		if len(typ.Args) != 2 || len(value.Args) != 1 {
+			return value, errors.New("invalid or value for type")
+		}
+
+		switch value.Prim {
+		case "Left":
+			return readableArgs(value, typ.Args[:1])
+		case "Right":
+			return readableArgs(value, typ.Args[1:])
+		}
+	case "list", "set":
+		if !value.IsSeq || len(typ.Args) != 1 {
+			return value, nil
+		}
+
+		for i := range value.Seq {
+			v, err := readable(value.Seq[i], typ.Args[0])
+			if err != nil {
+				return value, err
+			}
+			value.Seq[i] = v
+		}
+	case "map", "big_map":
+		if !value.IsSeq || len(typ.Args) != 2 {
+			return value, nil
+		}
+
+		for i := range value.Seq {
+			v, err := readableArgs(value.Seq[i], typ.Args)
+			if err != nil {
+				return value, err
+			}
+			value.Seq[i] = v
+		}
+	}
+
+	return value, nil
+}
+
+func readableBytes(value michelineNode, prim string, read func(r *reader) (string, error)) (michelineNode, error) {
+	if value.Bytes == nil {
+		return value, nil
+	}
+
+	v, err := hex.DecodeString(*value.Bytes)
+	if err != nil {
+		return value, errors.Wrapf(err, "invalid %s", prim)
+	}
+
+	r := &reader{buf: v}
+	s, err := read(r)
+	if err != nil {
+		return value, errors.Wrapf(err, "invalid %s", prim)
+	}
+
+	if r.remaining() != 0 {
+		return value, errors.Errorf("invalid %s: unexpected trailing bytes", prim)
+	}
+
+	return michelineNode{String: &s}, nil
+}
+
+// readableArgs converts the args of value with types, which must be as many
+func readableArgs(value michelineNode, types []michelineNode) (michelineNode, error) {
+	if len(value.Args) != len(types) {
+		return value, errors.Errorf("expected %d args for %s but got %d", len(types), value.Prim, len(value.Args))
+	}
+
+	for i := range value.Args {
+		v, err := readable(value.Args[i], types[i])
+		if err != nil {
+			return value, err
+		}
+		value.Args[i] = v
+	}
+
+	return value, nil
+}
+
+/*
+readablePair converts a pair, which is either a Pair or a sequence of its right combed values. Types and values
+can be combed differently, so the last value takes the remaining types and the last type is uncombed to cover the
+remaining values.
+*/
+func readablePair(value michelineNode, types []michelineNode) (michelineNode, error) {
+	values := value.Args
+	if value.IsSeq {
+		values = value.Seq
+	} else if value.Prim != "Pair" {
+		return value, errors.Errorf("expected Pair but got %s", value.Prim)
+	}
+
+	for len(types) < len(values) && len(types) > 0 && types[len(types)-1].Prim == "pair" {
+		types = append(types[:len(types)-1:len(types)-1], types[len(types)-1].Args...)
+	}
+
+	if len(values) == 0 || len(types) < len(values) {
+		return value, errors.New("pair does not match its type")
+	}
+
+	for i := range values {
+		t := types[i]
+		if i == len(values)-1 && len(types) > len(values) {
+			t = michelineNode{Prim: "pair", Args: types[i:]}
+		}
+
+		v, err := readable(values[i], t)
+		if err != nil {
+			return value, err
+		}
+		values[i] = v
+	}
+
+	return value, nil
+}
+
+// michelineNode is a Micheline expression: a primitive application, an int, a string, bytes or a sequence
+type michelineNode struct {
+	Prim   string          `json:"prim,omitempty"`
+	Args   []michelineNode `json:"args,omitempty"`
+	Annots []string        `json:"annots,omitempty"`
+	Int    *string         `json:"int,omitempty"`
+	String *string         `json:"string,omitempty"`
+	Bytes  *string         `json:"bytes,omitempty"`
+	Seq    []michelineNode `json:"-"`
+	IsSeq  bool            `json:"-"`
+}
+
+type michelineObject michelineNode
+
+func (m *michelineNode) UnmarshalJSON(v []byte) error {
+	if len(bytes.TrimSpace(v)) > 0 && bytes.TrimSpace(v)[0] == '[' {
+		m.IsSeq = true
+		m.Seq = []michelineNode{}
+		return json.Unmarshal(v, &m.Seq)
+	}
+
+	return json.Unmarshal(v, (*michelineObject)(m))
+}
+
+func (m michelineNode) MarshalJSON() ([]byte, error) {
+	if m.IsSeq {
+		if m.Seq == nil {
+			return []byte("[]"), nil
+		}
+		return json.Marshal(m.Seq)
+	}
+
+	return json.Marshal(michelineObject(m))
+}
@@ -0,0 +1,189 @@
+package forge
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/goat-systems/go-tezos/v4/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/valyala/fastjson"
+)
+
+func Test_Unpack(t *testing.T) {
+	cases := []struct {
+		name        string
+		input       string
+		wantErr     bool
+		containsErr string
+		want        string
+	}{
+		{
+			"is successful with string",
+			"0501000000057461636f73",
+			false,
+			"",
+			`{"string":"tacos"}`,
+		},
+		{
+			"is successful with negative int",
+			"050049",
+			false,
+			"",
+			`{"int":"-9"}`,
+		},
+		{
+			"is successful with bytes",
+			"050a00000003abcdef",
+			false,
+			"",
+			`{"bytes":"abcdef"}`,
+		},
+		{
+			"is successful with prims, annotations and sequences",
+			"0507070002020000000d0505030b0509030b0200000000",
+			false,
+			"",
+			`{"prim":"Pair","args":[{"int":"2"},[{"prim":"Left","args":[{"prim":"Unit"}]},{"prim":"Some","args":[{"prim":"Unit"}]},[]]]}`,
+		},
+		{
+			"handles missing prefix",
+			"01000000057461636f73",
+			true,
+			"packed data must start with 05",
+			"",
+		},
+		{
+			"handles trailing bytes",
+			"05004900",
+			true,
+			"unexpected trailing bytes",
+			"",
+		},
+		{
+			"handles invalid hex",
+			"05zz",
+			true,
+			"invalid hex",
+			"",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := Unpack(tt.input)
+			testutils.CheckErr(t, tt.wantErr, tt.containsErr, err)
+			if !tt.wantErr {
+				assert.JSONEq(t, tt.want, string(v))
+			}
+		})
+	}
+}
+
+func Test_Unpack_RoundTrip(t *testing.T) {
+	micheline := `[{"prim":"DIP","args":[{"int":"2"},[{"prim":"DROP"}]]},{"prim":"PUSH","args":[{"prim":"nat","annots":[":amount"]},{"int":"1000000"}]},{"prim":"LAMBDA","args":[{"prim":"unit"},{"prim":"unit"},[]]},{"string":"tz1L8fUQLuwRuywTZUP5JUw9LL3kJa8LMfoo"},{"bytes":""}]`
+
+	v, err := fastjson.Parse(micheline)
+	testutils.CheckErr(t, false, "", err)
+
+	packed, err := forgeMicheline(v)
+	testutils.CheckErr(t, false, "", err)
+
+	unpacked, err := Unpack("05" + hex.EncodeToString(packed))
+	testutils.CheckErr(t, false, "", err)
+	assert.JSONEq(t, micheline, string(unpacked))
+}
+
+func Test_UnpackWithType(t *testing.T) {
+	forged := func(micheline string) string {
+		v, err := fastjson.Parse(micheline)
+		testutils.CheckErr(t, false, "", err)
+
+		packed, err := forgeMicheline(v)
+		testutils.CheckErr(t, false, "", err)
+		return "05" + hex.EncodeToString(packed)
+	}
+
+	cases := []struct {
+		name        string
+		value       string
+		typ         string
+		wantErr     bool
+		containsErr string
+		want        string
+	}{
+		{
+			"is successful with address",
+			`{"bytes":"0000056a59972593bdc74a5295671c8f5d43c21348da"}`,
+			`{"prim":"address"}`,
+			false,
+			"",
+			`{"string":"tz1L8fUQLuwRuywTZUP5JUw9LL3kJa8LMfoo"}`,
+		},
+		{
+			"is successful with contract and entrypoint",
+			`{"bytes":"011d23c1d3d2f8a4ea5e8784b8f7ecf2ad304c0fe6007472616e73666572"}`,
+			`{"prim":"contract","args":[{"prim":"unit"}]}`,
+			false,
+			"",
+			`{"string":"KT1BEqzn5Wx8uJrZNvuS9DVHmLvG9td3fDLi%transfer"}`,
+		},
+		{
+			"is successful with key and key hash",
+			`{"prim":"Pair","args":[{"bytes":"00544c5521bc0571400e1813a82b2179453794943dc3a298f8aa51e29ac7404ca3"},{"bytes":"00056a59972593bdc74a5295671c8f5d43c21348da"}]}`,
+			`{"prim":"pair","args":[{"prim":"key"},{"prim":"key_hash"}]}`,
+			false,
+			"",
+			`{"prim":"Pair","args":[{"string":"edpkuHMDkMz46HdRXYwom3xRwqk3zQ5ihWX4j8dwo2R2h8o4gPcbN5"},{"string":"tz1L8fUQLuwRuywTZUP5JUw9LL3kJa8LMfoo"}]}`,
+		},
+		{
+			"is successful with combed pairs",
+			`{"prim":"Pair","args":[{"int":"1"},{"bytes":"0000056a59972593bdc74a5295671c8f5d43c21348da"},{"int":"1600000000"}]}`,
+			`{"prim":"pair","args":[{"prim":"nat"},{"prim":"pair","args":[{"prim":"address"},{"prim":"timestamp"}]}]}`,
+			false,
+			"",
+			`{"prim":"Pair","args":[{"int":"1"},{"string":"tz1L8fUQLuwRuywTZUP5JUw9LL3kJa8LMfoo"},{"string":"2020-09-13T12:26:40Z"}]}`,
+		},
+		{
+			"is successful with pair sequence against comb type",
+			`[{"int":"1"},{"bytes":"0000056a59972593bdc74a5295671c8f5d43c21348da"},{"int":"2"}]`,
+			`{"prim":"pair","args":[{"prim":"nat"},{"prim":"address"},{"prim":"nat"}]}`,
+			false,
+			"",
+			`[{"int":"1"},{"string":"tz1L8fUQLuwRuywTZUP5JUw9LL3kJa8LMfoo"},{"int":"2"}]`,
+		},
+		{
+			"is successful with map, option and or",
+			`[{"prim":"Elt","args":[{"bytes":"00056a59972593bdc74a5295671c8f5d43c21348da"},{"prim":"Some","args":[{"prim":"Right","args":[{"bytes":"7a06a770"}]}]}]}]`,
+			`{"prim":"map","args":[{"prim":"key_hash"},{"prim":"option","args":[{"prim":"or","args":[{"prim":"nat"},{"prim":"chain_id"}]}]}]}`,
+			false,
+			"",
+			`[{"prim":"Elt","args":[{"string":"tz1L8fUQLuwRuywTZUP5JUw9LL3kJa8LMfoo"},{"prim":"Some","args":[{"prim":"Right","args":[{"string":"NetXdQprcVkpaWU"}]}]}]}]`,
+		},
+		{
+			"handles invalid address",
+			`{"bytes":"0000056a"}`,
+			`{"prim":"address"}`,
+			true,
+			"invalid address",
+			"",
+		},
+		{
+			"handles value not matching type",
+			`{"int":"1"}`,
+			`{"prim":"pair","args":[{"prim":"nat"},{"prim":"nat"}]}`,
+			true,
+			"expected Pair",
+			"",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := UnpackWithType(forged(tt.value), tt.typ)
+			testutils.CheckErr(t, tt.wantErr, tt.containsErr, err)
+			if !tt.wantErr {
+				assert.JSONEq(t, tt.want, string(v))
+			}
+		})
+	}
+}
@@ -20,10 +20,18 @@ func B58cencode(payload []byte, prefix []byte) string {
 	return b58c
 }
 
-// B58cdecode -
-func B58cdecode(payload string, prefix []byte) []byte {
-	b58c, _ := Decode(payload)
-	return b58c[len(prefix):]
+// B58cdecode decodes a base58 encoded payload with prefix, and returns it without the prefix
+func B58cdecode(payload string, prefix []byte) ([]byte, error) {
+	b58c, err := Decode(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.HasPrefix(b58c, prefix) {
+		return nil, errors.New("invalid prefix")
+	}
+
+	return b58c[len(prefix):], nil
 }
 
 // Encode -
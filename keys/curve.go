@@ -2,29 +2,34 @@ package keys
 
 import (
 	"fmt"
+
+	"github.com/goat-systems/go-tezos/v4/tezos"
 )
 
 // ECKind is the key type
-type ECKind string
+type ECKind = tezos.ECKind
 
 const (
 	// Ed25519 https://tools.ietf.org/html/rfc8032
-	Ed25519 ECKind = "Ed25519"
+	Ed25519 = tezos.Ed25519
 	// Secp256k1 https://tools.ietf.org/html/rfc4492
-	Secp256k1 ECKind = "Secp256k1"
+	Secp256k1 = tezos.Secp256k1
 	// NistP256 https://tools.ietf.org/html/rfc5656
-	NistP256 ECKind = "NistP256"
+	NistP256 = tezos.NistP256
 )
 
 type iCurve interface {
 	addressPrefix() []byte
 	publicKeyPrefix() []byte
 	privateKeyPrefix() []byte
+	encryptedPrivateKeyPrefix() []byte
 	signaturePrefix() []byte
 	getECKind() ECKind
 	getPrivateKey(v []byte) []byte
 	getPublicKey(privateKey []byte) ([]byte, error)
+	validatePublicKey(publicKey []byte) error
 	sign(msg []byte, privateKey []byte) (Signature, error)
+	verify(hash []byte, signature []byte, publicKey []byte) (bool, error)
 }
 
 func getCurve(kind ECKind) iCurve {
@@ -52,3 +57,12 @@ func getCurveByPrefix(prefix string) (iCurve, error) {
 
 	return nil, fmt.Errorf("failed to find curve with prefix '%s'", prefix)
 }
+
+// leftPad pads v with leading zeros up to length l
+func leftPad(v []byte, l int) []byte {
+	if len(v) >= l {
+		return v
+	}
+
+	return append(make([]byte, l-len(v)), v...)
+}
@@ -0,0 +1,207 @@
+package keys
+
+import (
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// hardenedOffset is added to the index of hardened path segments
+const hardenedOffset uint32 = 0x80000000
+
+// DefaultDerivationPath is the path Tezos wallets derive their first account at.
+const DefaultDerivationPath = "m/44'/1729'/0'/0'"
+
+/*
+DerivationPath returns the path Tezos wallets derive the account at index at (m/44'/1729'/<account>'/0').
+
+Example:
+	for i := 0; i < 100; i++ {
+		key, err := keys.FromSeedWithPath(seed, keys.DerivationPath(i), keys.Ed25519)
+		...
+	}
+*/
+func DerivationPath(account int) string {
+	return fmt.Sprintf("m/44'/1729'/%d'/0'", account)
+}
+
+/*
+FromMnemonicWithPath returns the key at a derivation path of a bip39 mnemonic, the way hierarchical deterministic
+wallets such as Temple, Kukai and Ledger derive keys: with SLIP-10 for Ed25519 and BIP32 for Secp256k1 and
+NistP256. Unlike FromMnemonic, the passphrase is only the optional bip39 password and not the fundraiser
+email and password.
+
+Parameters:
+
+	mnemonic:
+		The bip39 mnemonic.
+
+	passwd:
+		The optional bip39 password, empty if the wallet has none.
+
+	path:
+		The derivation path (e.g. m/44'/1729'/0'/0'). Hardened segments end with ' or h. Ed25519 only supports
+		hardened segments.
+
+	kind:
+		The curve of the key.
+
+Example:
+	key, err := keys.FromMnemonicWithPath(mnemonic, "", keys.DefaultDerivationPath, keys.Ed25519)
+*/
+func FromMnemonicWithPath(mnemonic, passwd, path string, kind ECKind) (*Key, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, passwd)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to import key")
+	}
+
+	return FromSeedWithPath(seed, path, kind)
+}
+
+/*
+FromSeedWithPath returns the key at a derivation path of a seed, such as the bip39 seed of a mnemonic. Deriving many
+keys from the seed rather than the mnemonic saves stretching the mnemonic for every key. See FromMnemonicWithPath.
+
+Example:
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, "")
+	if err != nil {
+		return err
+	}
+
+	key, err := keys.FromSeedWithPath(seed, keys.DerivationPath(1), keys.Secp256k1)
+*/
+func FromSeedWithPath(seed []byte, path string, kind ECKind) (*Key, error) {
+	indexes, err := parseDerivationPath(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to import key")
+	}
+
+	privKey, err := derive(seed, indexes, kind)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to import key at '%s'", path)
+	}
+
+	return key(privKey, kind)
+}
+
+// parseDerivationPath returns the indexes of a path such as m/44'/1729'/0'/0'
+func parseDerivationPath(path string) ([]uint32, error) {
+	segments := strings.Split(path, "/")
+	if segments[0] != "m" {
+		return nil, errors.Errorf("invalid derivation path '%s': must start with m", path)
+	}
+
+	indexes := make([]uint32, 0, len(segments)-1)
+	for _, segment := range segments[1:] {
+		hardened := strings.HasSuffix(segment, "'") || strings.HasSuffix(segment, "h") || strings.HasSuffix(segment, "H")
+		if hardened {
+			segment = segment[:len(segment)-1]
+		}
+
+		i, err := strconv.ParseUint(segment, 10, 32)
+		if err != nil || uint32(i) >= hardenedOffset {
+			return nil, errors.Errorf("invalid derivation path '%s': invalid index '%s'", path, segment)
+		}
+
+		if hardened {
+			i += uint64(hardenedOffset)
+		}
+		indexes = append(indexes, uint32(i))
+	}
+
+	return indexes, nil
+}
+
+/*
+derive derives the private key at indexes from seed as specified by SLIP-10, which is BIP32 for Secp256k1 and
+extends it to Ed25519 and NistP256: https://github.com/satoshilabs/slips/blob/master/slip-0010.md
+*/
+func derive(seed []byte, indexes []uint32, kind ECKind) ([]byte, error) {
+	var (
+		hmacKey string
+		n       *big.Int
+	)
+
+	switch kind {
+	case Ed25519:
+		hmacKey = "ed25519 seed"
+	case Secp256k1:
+		hmacKey = "Bitcoin seed"
+		n = ethcrypto.S256().Params().N
+	case NistP256:
+		hmacKey = "Nist256p1 seed"
+		n = elliptic.P256().Params().N
+	default:
+		return nil, errors.Errorf("unsupported curve '%s'", kind)
+	}
+
+	i := hmacSHA512([]byte(hmacKey), seed)
+	for n != nil && !validScalar(i[:32], n) {
+		i = hmacSHA512([]byte(hmacKey), i)
+	}
+	privKey, chainCode := i[:32], i[32:]
+
+	curve := getCurve(kind)
+	for _, index := range indexes {
+		data := make([]byte, 0, 37)
+		if index >= hardenedOffset {
+			data = append(append(data, 0), privKey...)
+		} else if n == nil {
+			return nil, errors.New("ed25519 only supports hardened derivation")
+		} else {
+			pubKey, err := curve.getPublicKey(privKey)
+			if err != nil {
+				return nil, err
+			}
+			data = append(data, pubKey...)
+		}
+		data = append(data, ser32(index)...)
+
+		i = hmacSHA512(chainCode, data)
+		if n == nil {
+			privKey, chainCode = i[:32], i[32:]
+			continue
+		}
+
+		for {
+			child := new(big.Int).SetBytes(i[:32])
+			child.Add(child, new(big.Int).SetBytes(privKey))
+			child.Mod(child, n)
+			if validScalar(i[:32], n) && child.Sign() != 0 {
+				privKey, chainCode = leftPad(child.Bytes(), 32), i[32:]
+				break
+			}
+
+			i = hmacSHA512(chainCode, append(append([]byte{1}, i[32:]...), ser32(index)...))
+		}
+	}
+
+	return privKey, nil
+}
+
+// validScalar reports whether v is a valid private key for a curve of order n
+func validScalar(v []byte, n *big.Int) bool {
+	k := new(big.Int).SetBytes(v)
+	return k.Sign() != 0 && k.Cmp(n) < 0
+}
+
+func ser32(i uint32) []byte {
+	v := make([]byte, 4)
+	binary.BigEndian.PutUint32(v, i)
+	return v
+}
+
+func hmacSHA512(key, data []byte) []byte {
+	mac := hmac.New(sha512.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
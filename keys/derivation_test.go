@@ -0,0 +1,119 @@
+package keys
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/goat-systems/go-tezos/v4/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// Test vectors from https://github.com/satoshilabs/slips/blob/master/slip-0010.md
+func Test_FromSeedWithPath(t *testing.T) {
+	type input struct {
+		seed string
+		path string
+		kind ECKind
+	}
+
+	type want struct {
+		err         bool
+		errContains string
+		privKey     string
+	}
+
+	cases := []struct {
+		name  string
+		input input
+		want  want
+	}{
+		{
+			"is successful with ed25519 master key",
+			input{"000102030405060708090a0b0c0d0e0f", "m", Ed25519},
+			want{false, "", "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7"},
+		},
+		{
+			"is successful with ed25519",
+			input{"000102030405060708090a0b0c0d0e0f", "m/0'/1'/2'/2'/1000000000'", Ed25519},
+			want{false, "", "8f94d394a8e8fd6b1bc2f3f49f5c47e385281d5c17e65324b0f62483e37e8793"},
+		},
+		{
+			"is successful with secp256k1",
+			input{"000102030405060708090a0b0c0d0e0f", "m/0h/1/2h/2/1000000000", Secp256k1},
+			want{false, "", "471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8"},
+		},
+		{
+			"is successful with p256",
+			input{"000102030405060708090a0b0c0d0e0f", "m/0'/1", NistP256},
+			want{false, "", "284e9d38d07d21e4e281b645089a94f4cf5a5a81369acf151a1c3a57f18b2129"},
+		},
+		{
+			"is successful with p256 derivation retry",
+			input{"000102030405060708090a0b0c0d0e0f", "m/28578'/33941", NistP256},
+			want{false, "", "092154eed4af83e078ff9b84322015aefe5769e31270f62c3f66c33888335f3a"},
+		},
+		{
+			"is successful with p256 seed retry",
+			input{"a7305bc8df8d0951f0cb224c0e95d7707cbdf2c6ce7e8d481fec69c7ff5e9446", "m", NistP256},
+			want{false, "", "3b8c18469a4634517d6d0b65448f8e6c62091b45540a1743c5846be55d47d88f"},
+		},
+		{
+			"handles non hardened ed25519 derivation",
+			input{"000102030405060708090a0b0c0d0e0f", "m/0'/1", Ed25519},
+			want{true, "failed to import key at 'm/0'/1': ed25519 only supports hardened derivation", ""},
+		},
+		{
+			"handles invalid path",
+			input{"000102030405060708090a0b0c0d0e0f", "44'/1729'", Ed25519},
+			want{true, "invalid derivation path '44'/1729'': must start with m", ""},
+		},
+		{
+			"handles invalid index",
+			input{"000102030405060708090a0b0c0d0e0f", "m/2147483648'", Secp256k1},
+			want{true, "invalid index '2147483648'", ""},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			seed, err := hex.DecodeString(tt.input.seed)
+			testutils.CheckErr(t, false, "", err)
+
+			key, err := FromSeedWithPath(seed, tt.input.path, tt.input.kind)
+			testutils.CheckErr(t, tt.want.err, tt.want.errContains, err)
+			if !tt.want.err {
+				assert.Equal(t, tt.want.privKey, hex.EncodeToString(key.GetBytes()[:32]))
+			}
+		})
+	}
+}
+
+func Test_FromMnemonicWithPath(t *testing.T) {
+	mnemonic := "normal dash crumble neutral reflect parrot know stairs culture fault check whale flock dog scout"
+
+	for _, kind := range []ECKind{Ed25519, Secp256k1, NistP256} {
+		t.Run(string(kind), func(t *testing.T) {
+			key, err := FromMnemonicWithPath(mnemonic, "", DefaultDerivationPath, kind)
+			testutils.CheckErr(t, false, "", err)
+
+			seed, err := bip39.NewSeedWithErrorChecking(mnemonic, "")
+			testutils.CheckErr(t, false, "", err)
+
+			first, err := FromSeedWithPath(seed, DerivationPath(0), kind)
+			testutils.CheckErr(t, false, "", err)
+			assert.Equal(t, key.GetSecretKey(), first.GetSecretKey())
+
+			second, err := FromSeedWithPath(seed, DerivationPath(1), kind)
+			testutils.CheckErr(t, false, "", err)
+			assert.NotEqual(t, first.PubKey.GetAddress(), second.PubKey.GetAddress())
+
+			withPasswd, err := FromMnemonicWithPath(mnemonic, "passwd", DefaultDerivationPath, kind)
+			testutils.CheckErr(t, false, "", err)
+			assert.NotEqual(t, first.PubKey.GetAddress(), withPasswd.PubKey.GetAddress())
+		})
+	}
+
+	_, err := FromMnemonicWithPath("normal dash crumble", "", DefaultDerivationPath, Ed25519)
+	testutils.CheckErr(t, true, "failed to import key", err)
+}
@@ -24,6 +24,15 @@ func (e *ed25519Curve) privateKeyPrefix() []byte {
 	return []byte{43, 246, 78, 7}
 }
 
+// seedPrefix is the prefix of the 32 byte seeds octez-client stores unencrypted ed25519 keys as (edsk3...)
+func (e *ed25519Curve) seedPrefix() []byte {
+	return []byte{13, 15, 58, 7}
+}
+
+func (e *ed25519Curve) encryptedPrivateKeyPrefix() []byte {
+	return []byte{7, 90, 60, 179, 41}
+}
+
 func (e *ed25519Curve) signaturePrefix() []byte {
 	return []byte{9, 245, 205, 134, 18}
 }
@@ -36,6 +45,14 @@ func (e *ed25519Curve) getPrivateKey(v []byte) []byte {
 	return ed25519.NewKeyFromSeed(v[:32])
 }
 
+func (e *ed25519Curve) validatePublicKey(publicKey []byte) error {
+	if len(publicKey) != ed25519.PublicKeySize {
+		return errors.Errorf("invalid public key length %d", len(publicKey))
+	}
+
+	return nil
+}
+
 func (e *ed25519Curve) getPublicKey(privateKey []byte) ([]byte, error) {
 	pubKey, ok := ed25519.PrivateKey(privateKey).Public().(ed25519.PublicKey)
 	if !ok {
@@ -60,7 +77,18 @@ func (e *ed25519Curve) sign(msg []byte, privateKey []byte) (Signature, error) {
 	}
 
 	return Signature{
-		Bytes:  ed25519.Sign(ed25519.PrivateKey(privateKey), hash.Sum([]byte{})),
-		prefix: e.signaturePrefix(),
+		Bytes: ed25519.Sign(ed25519.PrivateKey(privateKey), hash.Sum([]byte{})),
+		Curve: e.getECKind(),
 	}, nil
 }
+
+func (e *ed25519Curve) verify(hash []byte, signature []byte, publicKey []byte) (bool, error) {
+	if len(publicKey) != ed25519.PublicKeySize {
+		return false, errors.Errorf("invalid public key length %d", len(publicKey))
+	}
+	if len(signature) != ed25519.SignatureSize {
+		return false, errors.Errorf("invalid signature length %d", len(signature))
+	}
+
+	return ed25519.Verify(ed25519.PublicKey(publicKey), hash, signature), nil
+}
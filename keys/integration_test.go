@@ -59,10 +59,10 @@ func Test_OperationWithKey(t *testing.T) {
 			transaction := rpc.Transaction{
 				Kind:         rpc.TRANSACTION,
 				Source:       key.PubKey.address,
-				Fee:          "2941",
+				Fee:          rpc.NewMutez(2941),
 				Counter:      strconv.Itoa((counter + 1)),
 				GasLimit:     "26283",
-				Amount:       "1",
+				Amount:       rpc.NewMutez(1),
 				StorageLimit: "0",
 				Destination:  "tz1RomaiWJV3NFDZWTMVR2aEeHknsn3iF5Gi",
 			}
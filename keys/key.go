@@ -33,6 +33,33 @@ func Generate(kind ECKind) (*Key, error) {
 	return key(token, kind)
 }
 
+/*
+GenerateWithMnemonic returns a new key along with the 24 word bip39 mnemonic it is derived from. The key is the
+first account of the mnemonic (see DefaultDerivationPath), so FromMnemonicWithPath and HD wallets such as Temple and
+Kukai import the same key from the mnemonic.
+
+Example:
+	key, mnemonic, err := keys.GenerateWithMnemonic(keys.Ed25519)
+*/
+func GenerateWithMnemonic(kind ECKind) (*Key, string, error) {
+	entropy, err := bip39.NewEntropy(256)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to generate key")
+	}
+
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to generate key")
+	}
+
+	key, err := FromMnemonicWithPath(mnemonic, "", DefaultDerivationPath, kind)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to generate key")
+	}
+
+	return key, mnemonic, nil
+}
+
 // FromBytes returns a new key from a private key in byte form
 func FromBytes(privKey []byte, kind ECKind) (*Key, error) {
 	return key(privKey, kind)
@@ -57,7 +84,10 @@ func FromBase64(privKey string, kind ECKind) (*Key, error) {
 	return key(v, kind)
 }
 
-// FromBase58 returns a new key from a private key in base58 form
+/*
+FromBase58 returns a new key from a private key in base58 form. Ed25519 keys may be given as the 64 byte secret key
+(edsk, 98 characters) or as the 32 byte seed (edsk, 54 characters) octez-client stores unencrypted keys as.
+*/
 func FromBase58(privKey string, kind ECKind) (*Key, error) {
 	if len(privKey) < 4 {
 		return nil, errors.New("failed to import key: invalid key length")
@@ -68,41 +98,48 @@ func FromBase58(privKey string, kind ECKind) (*Key, error) {
 		return nil, errors.Wrap(err, "failed to import key")
 	}
 
-	return key(tzcrypt.B58cdecode(privKey, curve.privateKeyPrefix()), curve.getECKind())
+	prefix := curve.privateKeyPrefix()
+	if ed, ok := curve.(*ed25519Curve); ok && len(privKey) == 54 {
+		prefix = ed.seedPrefix()
+	}
+
+	v, err := tzcrypt.B58cdecode(privKey, prefix)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to import key")
+	}
+
+	return key(v, curve.getECKind())
 }
 
 // FromEncryptedSecret returns a new key from an encrypted private key
 func FromEncryptedSecret(esk, passwd string) (*Key, error) {
+	if len(esk) < 5 {
+		return &Key{}, errors.New("failed to import key: invalid key length")
+	}
+
 	curve, err := getCurveByPrefix(esk[:5])
 	if err != nil {
 		return &Key{}, err
 	}
 
-	// Convert key from base58 to []byte
-	b58c, err := tzcrypt.Decode(esk)
+	// Convert key from base58 to []byte, and strip off the prefix
+	esb, err := tzcrypt.B58cdecode(esk, curve.encryptedPrivateKeyPrefix())
 	if err != nil {
 		return &Key{}, errors.Wrap(err, "failed to import key")
 	}
 
-	// Strip off prefix and extract parts
-	esb := b58c[5:]
+	if len(esb) <= 8 {
+		return &Key{}, errors.New("failed to import key: invalid key length")
+	}
+
+	// Extract parts
 	salt := esb[:8]
 	esm := esb[8:] // encrypted key
 
-	// Convert string pw to []byte
-	passWd := []byte(passwd)
-
-	// Derive a key from password, salt and number of iterations
-	pbkdf2key := pbkdf2.Key(passWd, salt, 32768, 32, sha512.New)
-	var byteKey [32]byte
-	for i := range pbkdf2key {
-		byteKey[i] = pbkdf2key[i]
-	}
-
 	var out []byte
 	var emptyNonceBytes [24]byte
 
-	unencSecret, ok := secretbox.Open(out, esm, &emptyNonceBytes, &byteKey)
+	unencSecret, ok := secretbox.Open(out, esm, &emptyNonceBytes, encryptionKey(passwd, salt))
 	if !ok {
 		return &Key{}, errors.New("failed to import key: invalid password")
 	}
@@ -110,7 +147,16 @@ func FromEncryptedSecret(esk, passwd string) (*Key, error) {
 	return key(unencSecret, curve.getECKind())
 }
 
-// FromMnemonic returns a new key from a mnemonic
+// encryptionKey derives the key secret keys are encrypted with from a password and salt
+func encryptionKey(passwd string, salt []byte) *[32]byte {
+	pbkdf2key := pbkdf2.Key([]byte(passwd), salt, 32768, 32, sha512.New)
+	var byteKey [32]byte
+	copy(byteKey[:], pbkdf2key)
+
+	return &byteKey
+}
+
+// FromMnemonic returns a new key from a mnemonic of the fundraiser scheme. See FromMnemonicWithPath for the keys of HD wallets.
 func FromMnemonic(mnemonic, email, passwd string, kind ECKind) (*Key, error) {
 	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, fmt.Sprintf("%s%s", email, passwd))
 	if err != nil {
@@ -147,26 +193,49 @@ func (k *Key) GetSecretKey() string {
 	return tzcrypt.B58cencode(k.privKey, k.curve.privateKeyPrefix())
 }
 
-// SignHex will sign a hex encoded string
+/*
+GetEncryptedSecretKey will return the base58 encoded key encrypted with passwd (edesk, spesk or p2esk), in the
+format octez-client stores encrypted keys in and FromEncryptedSecret reads.
+
+Example:
+	esk, err := key.GetEncryptedSecretKey("password12345##") // edesk1..., salted differently on every call
+*/
+func (k *Key) GetEncryptedSecretKey(passwd string) (string, error) {
+	salt := make([]byte, 8)
+	if _, err := rand.Read(salt); err != nil {
+		return "", errors.Wrap(err, "failed to encrypt secret key")
+	}
+
+	// Ed25519 keys are encrypted as their 32 byte seed, and the keys of the other curves are 32 bytes
+	var emptyNonceBytes [24]byte
+	esm := secretbox.Seal(nil, k.privKey[:32], &emptyNonceBytes, encryptionKey(passwd, salt))
+
+	return tzcrypt.B58cencode(append(salt, esm...), k.curve.encryptedPrivateKeyPrefix()), nil
+}
+
+// SignHex will sign a hex encoded generic operation, see SignBytes
 func (k *Key) SignHex(msg string) (Signature, error) {
 	bytes, err := hex.DecodeString(msg)
 	if err != nil {
 		return Signature{}, errors.Wrap(err, "failed to hex decode message")
 	}
 
-	return k.curve.sign(checkAndAddWaterMark(bytes), k.privKey)
+	return k.SignBytes(bytes)
 }
 
-// SignBytes will sign a byte message
+/*
+SignBytes will sign a generic operation, such as a forged transaction. The generic operation watermark (0x03) is
+prepended unless msg already starts with it, so a forged operation whose branch starts with 0x03 must be signed with
+Sign(msg, GenericOperationWatermark) instead. Use Sign to sign blocks and consensus operations.
+*/
 func (k *Key) SignBytes(msg []byte) (Signature, error) {
 	return k.curve.sign(checkAndAddWaterMark(msg), k.privKey)
 }
 
+// checkAndAddWaterMark prepends the generic operation watermark to v unless v already starts with it
 func checkAndAddWaterMark(v []byte) []byte {
-	if v != nil {
-		if v[0] != byte(3) {
-			v = append([]byte{3}, v...)
-		}
+	if len(v) > 0 && v[0] != byte(WatermarkGenericOperation) {
+		v = GenericOperationWatermark.watermark(v)
 	}
 
 	return v
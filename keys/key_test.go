@@ -2,11 +2,14 @@ package keys
 
 import (
 	"encoding/hex"
+	"strings"
 	"testing"
 
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	tzcrypt "github.com/goat-systems/go-tezos/v4/internal/crypto"
 	"github.com/goat-systems/go-tezos/v4/internal/testutils"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/blake2b"
 )
 
 func Test_FromEncryptedSecret(t *testing.T) {
@@ -113,6 +116,57 @@ func Test_FromEncryptedSecret(t *testing.T) {
 	}
 }
 
+func Test_GetEncryptedSecretKey(t *testing.T) {
+	cases := []struct {
+		name      string
+		secretKey string
+		prefix    string
+	}{
+		{"is successful with ed25519", "edskRsPBsKuULoLTEQV2R9UbvSZbzFqvoESvp1mYyQJU8xi9mJamt88r5uTXbWQpVHjSiPWWtnoyqTCuSLQLxbEKUXfwwTccsF", "edesk"},
+		{"is successful with secp256k1", "spsk2psNeAQ88pKFnZikoZNb37zRbDmaGgQUtYrwwJZT3RcUspwL7N", "spesk"},
+		{"is successful with p256", "p2sk3UumbKMrb6Wo1Jm5qTSMhUrCyAFTK4LMWgVma9njNLGc2Wcx9S", "p2esk"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := FromBase58(tt.secretKey, "")
+			testutils.CheckErr(t, false, "", err)
+
+			esk, err := key.GetEncryptedSecretKey("abcd1234")
+			testutils.CheckErr(t, false, "", err)
+			assert.True(t, strings.HasPrefix(esk, tt.prefix))
+			assert.Len(t, esk, 88)
+
+			decrypted, err := FromEncryptedSecret(esk, "abcd1234")
+			testutils.CheckErr(t, false, "", err)
+			assert.Equal(t, tt.secretKey, decrypted.GetSecretKey())
+
+			_, err = FromEncryptedSecret(esk, "abcd12345")
+			testutils.CheckErr(t, true, "failed to import key: invalid password", err)
+
+			// every export is salted differently
+			other, err := key.GetEncryptedSecretKey("abcd1234")
+			testutils.CheckErr(t, false, "", err)
+			assert.NotEqual(t, esk, other)
+		})
+	}
+}
+
+func Test_GenerateWithMnemonic(t *testing.T) {
+	for _, kind := range []ECKind{Ed25519, Secp256k1, NistP256} {
+		t.Run(string(kind), func(t *testing.T) {
+			key, mnemonic, err := GenerateWithMnemonic(kind)
+			testutils.CheckErr(t, false, "", err)
+			assert.Len(t, strings.Fields(mnemonic), 24)
+
+			imported, err := FromMnemonicWithPath(mnemonic, "", DefaultDerivationPath, kind)
+			testutils.CheckErr(t, false, "", err)
+			assert.Equal(t, key.GetSecretKey(), imported.GetSecretKey())
+			assert.Equal(t, key.PubKey.GetAddress(), imported.PubKey.GetAddress())
+		})
+	}
+}
+
 func Test_FromBytes(t *testing.T) {
 	privKey := []byte{117, 121, 196, 136, 31, 185, 152, 208, 67, 65, 123, 124, 4, 88, 42, 161, 81, 121, 241, 37, 197, 48, 62, 30, 229, 106, 150, 120, 3, 77, 149, 176}
 	key, err := FromBytes(privKey, Ed25519)
@@ -155,6 +209,12 @@ func Test_FromBase58(t *testing.T) {
 	assert.Equal(t, "edskRsPBsKuULoLTEQV2R9UbvSZbzFqvoESvp1mYyQJU8xi9mJamt88r5uTXbWQpVHjSiPWWtnoyqTCuSLQLxbEKUXfwwTccsF", key.GetSecretKey())
 	assert.Equal(t, "edpkuHMDkMz46HdRXYwom3xRwqk3zQ5ihWX4j8dwo2R2h8o4gPcbN5", key.PubKey.GetPublicKey())
 	assert.Equal(t, "tz1L8fUQLuwRuywTZUP5JUw9LL3kJa8LMfoo", key.PubKey.GetAddress())
+
+	// the seed of the bootstrap1 account of the octez sandbox
+	key, err = FromBase58("edsk3gUfUPyBSfrS9CCgmCiQsTCHGkviBDusMxDJstFtojtc1zcpsh", Ed25519)
+	testutils.CheckErr(t, false, "", err)
+	assert.Equal(t, "edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav", key.PubKey.GetPublicKey())
+	assert.Equal(t, "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", key.PubKey.GetAddress())
 }
 
 func Test_FromMnemonic(t *testing.T) {
@@ -206,3 +266,74 @@ func Test_FromMnemonic(t *testing.T) {
 		})
 	}
 }
+
+func Test_SignHex(t *testing.T) {
+	msg := "a732d3520eeaa3de98d78e5e5cb6c85f72204fd46feb9f76853841d4a701add36c0008ba0cb2fad622697145cf1665124096d25bc31ef44e0af44e00b960000008ba0cb2fad622697145cf1665124096d25bc31e00"
+
+	cases := []struct {
+		name      string
+		secretKey string
+		signature string
+	}{
+		{
+			"is successful with ed25519",
+			"edskRsPBsKuULoLTEQV2R9UbvSZbzFqvoESvp1mYyQJU8xi9mJamt88r5uTXbWQpVHjSiPWWtnoyqTCuSLQLxbEKUXfwwTccsF",
+			"edsigtyo7bF9fBTM8Ltn4MdbhYfifVq8cCnh1ade7XRM9mAxCNQnDtQQpdgsJWGBuXP3cFj7U19evJVGKezPxxc4Kqtr5SmgA4U",
+		},
+		{
+			// not produced by octez-client: the same signature is produced by libsecp256k1 with its default
+			// RFC 6979 nonces, which Test_SignHex_Libsecp256k1 checks
+			"is successful with secp256k1",
+			"spsk2psNeAQ88pKFnZikoZNb37zRbDmaGgQUtYrwwJZT3RcUspwL7N",
+			"spsig1DH4YKRKTM2ZuHgwrru7rghPPag1HH44gEFm3xpaDwU9ysqff6c28dPotshCQN5CuGvtAQ2j7fxnpozuyftoDcGSXaqDsK",
+		},
+		{
+			// not produced by octez-client: a regression vector of signRFC6979, whose P256 nonces are checked
+			// against the RFC's own vectors in Test_signRFC6979
+			"is successful with p256",
+			"p2sk3UumbKMrb6Wo1Jm5qTSMhUrCyAFTK4LMWgVma9njNLGc2Wcx9S",
+			"p2sigqDiojrZAovJJzxWj9zPFevXgZ5aGvNW6CMxHRyNQQNiUaKcdCcv1KQHH7ssk2omEm9TpEXtbu6FYmgxoDumvrNMvHQMHW",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := FromBase58(tt.secretKey, Ed25519)
+			testutils.CheckErr(t, false, "", err)
+
+			// signatures are deterministic, so signing twice gives the same signature
+			for i := 0; i < 2; i++ {
+				sig, err := key.SignHex(msg)
+				testutils.CheckErr(t, false, "", err)
+				assert.Equal(t, tt.signature, sig.ToBase58())
+			}
+
+			ok, err := key.PubKey.VerifyHex(msg, tt.signature)
+			testutils.CheckErr(t, false, "", err)
+			assert.True(t, ok)
+		})
+	}
+}
+
+func Test_SignHex_Libsecp256k1(t *testing.T) {
+	msg := "a732d3520eeaa3de98d78e5e5cb6c85f72204fd46feb9f76853841d4a701add36c0008ba0cb2fad622697145cf1665124096d25bc31ef44e0af44e00b960000008ba0cb2fad622697145cf1665124096d25bc31e00"
+
+	key, err := FromBase58("spsk2psNeAQ88pKFnZikoZNb37zRbDmaGgQUtYrwwJZT3RcUspwL7N", Secp256k1)
+	testutils.CheckErr(t, false, "", err)
+
+	sig, err := key.SignHex(msg)
+	testutils.CheckErr(t, false, "", err)
+
+	v, err := hex.DecodeString("03" + msg)
+	testutils.CheckErr(t, false, "", err)
+	digest := blake2b.Sum256(v)
+
+	privKey, err := ethcrypto.ToECDSA(key.privKey)
+	testutils.CheckErr(t, false, "", err)
+
+	// go-ethereum signs with libsecp256k1 (btcec without cgo), both with RFC 6979 nonces and low S values, and
+	// appends a recovery id
+	want, err := ethcrypto.Sign(digest[:], privKey)
+	testutils.CheckErr(t, false, "", err)
+	assert.Equal(t, want[:64], sig.Bytes)
+}
@@ -3,7 +3,6 @@ package keys
 import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
-	"crypto/rand"
 	"math/big"
 
 	"github.com/pkg/errors"
@@ -26,6 +25,10 @@ func (n *nistP256Curve) privateKeyPrefix() []byte {
 	return []byte{16, 81, 238, 189}
 }
 
+func (n *nistP256Curve) encryptedPrivateKeyPrefix() []byte {
+	return []byte{9, 48, 57, 115, 171}
+}
+
 func (n *nistP256Curve) signaturePrefix() []byte {
 	return []byte{54, 240, 44, 52}
 }
@@ -58,6 +61,11 @@ func (n *nistP256Curve) getPublicKey(privateKey []byte) ([]byte, error) {
 	return append(pref, pad[len(pad)-32:]...), nil
 }
 
+func (n *nistP256Curve) validatePublicKey(publicKey []byte) error {
+	_, err := decompressP256(publicKey)
+	return err
+}
+
 func (n *nistP256Curve) sign(msg []byte, privateKey []byte) (Signature, error) {
 	hash, err := blake2b.New(32, []byte{})
 	if err != nil {
@@ -72,20 +80,66 @@ func (n *nistP256Curve) sign(msg []byte, privateKey []byte) (Signature, error) {
 		return Signature{}, errors.Errorf("failed to sign operation: generic hash length %d does not match bytes length %d", i, len(msg))
 	}
 
-	var privKey ecdsa.PrivateKey
-	privKey.D = new(big.Int).SetBytes(privateKey)
-	privKey.PublicKey.Curve = elliptic.P256()
-	privKey.PublicKey.X, privKey.PublicKey.Y = privKey.PublicKey.Curve.ScalarBaseMult(privKey.D.Bytes())
-
-	r, ss, err := ecdsa.Sign(rand.Reader, &privKey, hash.Sum([]byte{}))
+	r, ss, err := signRFC6979(elliptic.P256(), new(big.Int).SetBytes(privateKey), hash.Sum([]byte{}))
 	if err != nil {
 		return Signature{}, err
 	}
 
-	signature := append(r.Bytes(), ss.Bytes()...)
+	signature := append(leftPad(r.Bytes(), 32), leftPad(ss.Bytes(), 32)...)
 
 	return Signature{
-		Bytes:  signature,
-		prefix: n.signaturePrefix(),
+		Bytes: signature,
+		Curve: n.getECKind(),
+	}, nil
+}
+
+func (n *nistP256Curve) verify(hash []byte, signature []byte, publicKey []byte) (bool, error) {
+	if len(signature) != 64 {
+		return false, errors.Errorf("invalid signature length %d", len(signature))
+	}
+
+	pubKey, err := decompressP256(publicKey)
+	if err != nil {
+		return false, errors.Wrap(err, "invalid public key")
+	}
+
+	r := new(big.Int).SetBytes(signature[:32])
+	ss := new(big.Int).SetBytes(signature[32:])
+
+	return ecdsa.Verify(pubKey, hash, r, ss), nil
+}
+
+// decompressP256 recovers the full point of a 33 byte compressed P256 public key.
+func decompressP256(publicKey []byte) (*ecdsa.PublicKey, error) {
+	if len(publicKey) != 33 || (publicKey[0] != 2 && publicKey[0] != 3) {
+		return nil, errors.New("public key is not a compressed point")
+	}
+
+	params := elliptic.P256().Params()
+	x := new(big.Int).SetBytes(publicKey[1:])
+	if x.Cmp(params.P) >= 0 {
+		return nil, errors.New("public key is not on the curve")
+	}
+
+	// y² = x³ - 3x + b
+	y := new(big.Int).Mul(x, x)
+	y.Mul(y, x)
+	threeX := new(big.Int).Lsh(x, 1)
+	threeX.Add(threeX, x)
+	y.Sub(y, threeX)
+	y.Add(y, params.B)
+	y.Mod(y, params.P)
+
+	if y.ModSqrt(y, params.P) == nil {
+		return nil, errors.New("public key is not on the curve")
+	}
+	if y.Bit(0) != uint(publicKey[0]&1) {
+		y.Sub(params.P, y)
+	}
+
+	return &ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     x,
+		Y:     y,
 	}, nil
 }
@@ -1,7 +1,10 @@
 package keys
 
 import (
+	"encoding/hex"
+
 	tzcrypt "github.com/goat-systems/go-tezos/v4/internal/crypto"
+	"github.com/goat-systems/go-tezos/v4/tezos"
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/blake2b"
 )
@@ -24,6 +27,47 @@ func newPubKey(v []byte, kind ECKind) (PubKey, error) {
 		return PubKey{}, errors.Wrap(err, "failed to import pub key")
 	}
 
+	return pubKeyFromBytes(pk, curve)
+}
+
+/*
+PubKeyFromBase58 returns a public key from its base58 encoded form (edpk, sppk or p2pk), such as the manager key of
+a contract. Secp256k1 and NistP256 keys must be compressed points on their curve.
+
+Example:
+	pubKey, err := keys.PubKeyFromBase58("edpkvS5QFv7KRGfa3b87gg9DBpxSm3NpSwnjhUjNBQrRUUR66F7C9g")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(pubKey.GetAddress()) // tz1KiAZmm1ShNEs38kxs5zk4AyLo2XssfTsT
+*/
+func PubKeyFromBase58(pk string) (PubKey, error) {
+	v, err := tezos.ParsePublicKey(pk)
+	if err != nil {
+		return PubKey{}, errors.Wrap(err, "failed to import pub key")
+	}
+
+	return PubKeyFromBytes(v.Bytes(), v.Curve())
+}
+
+/*
+PubKeyFromBytes returns a public key from its raw bytes: 32 bytes for Ed25519, and a 33 byte compressed point for
+Secp256k1 and NistP256.
+
+Example:
+	pubKey, err := keys.PubKeyFromBytes(raw, keys.Secp256k1)
+*/
+func PubKeyFromBytes(pk []byte, kind ECKind) (PubKey, error) {
+	curve := getCurve(kind)
+	if err := curve.validatePublicKey(pk); err != nil {
+		return PubKey{}, errors.Wrap(err, "failed to import pub key")
+	}
+
+	return pubKeyFromBytes(append([]byte{}, pk...), curve)
+}
+
+func pubKeyFromBytes(pk []byte, curve iCurve) (PubKey, error) {
 	hash, err := blake2b.New(20, []byte{})
 	if err != nil {
 		return PubKey{}, errors.Wrapf(err, "failed to import pub key: failed to generate public hash from public key %s", string(pk))
@@ -62,3 +106,70 @@ Example:
 func (p *PubKey) GetAddress() string {
 	return p.address
 }
+
+/*
+Verify checks a signature against a generic operation signed by the public key's private key. The message is
+watermarked and hashed the same way SignBytes does before it is signed. A curve specific signature (edsig, spsig1,
+p2sig) of another curve than the key's is an error, while a generic signature (sig) is checked against any key.
+*/
+func (p *PubKey) Verify(msg []byte, sig Signature) (bool, error) {
+	return p.verify(checkAndAddWaterMark(msg), sig)
+}
+
+/*
+VerifyWithWatermark checks a signature against a message signed with a watermark by the public key's private key,
+such as a block or an endorsement (see Sign).
+
+Example:
+	watermark, err := keys.NewWatermark(keys.WatermarkTenderbakeBlock, "NetXdQprcVkpaWU")
+	if err != nil {
+		return err
+	}
+
+	ok, err := pubKey.VerifyWithWatermark(header, watermark, signature)
+*/
+func (p *PubKey) VerifyWithWatermark(msg []byte, watermark Watermark, sig Signature) (bool, error) {
+	return p.verify(watermark.watermark(msg), sig)
+}
+
+/*
+verify checks sig against the already watermarked msg. A curve specific signature must be of the key's curve, and
+a generic signature (sig) is checked against the key's curve.
+*/
+func (p *PubKey) verify(msg []byte, sig Signature) (bool, error) {
+	if sig.Curve != "" && sig.Curve != p.curve.getECKind() {
+		return false, errors.Errorf("failed to verify signature: %s signature for %s public key", sig.Curve, p.curve.getECKind())
+	}
+
+	hash, err := blake2b.New(32, []byte{})
+	if err != nil {
+		return false, errors.Wrap(err, "failed to verify signature")
+	}
+
+	_, err = hash.Write(msg)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to verify signature")
+	}
+
+	ok, err := p.curve.verify(hash.Sum([]byte{}), sig.Bytes, p.pubKey)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to verify signature")
+	}
+
+	return ok, nil
+}
+
+// VerifyHex checks a base58 encoded signature (edsig, spsig1, p2sig or sig) against a hex encoded generic operation
+func (p *PubKey) VerifyHex(msg string, sig string) (bool, error) {
+	bytes, err := hex.DecodeString(msg)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to hex decode message")
+	}
+
+	signature, err := SignatureFromBase58(sig)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to verify signature")
+	}
+
+	return p.Verify(bytes, signature)
+}
@@ -0,0 +1,179 @@
+package keys
+
+import (
+	"fmt"
+	"testing"
+
+	tzcrypt "github.com/goat-systems/go-tezos/v4/internal/crypto"
+	"github.com/goat-systems/go-tezos/v4/internal/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Verify(t *testing.T) {
+	msg := "a732d3520eeaa3de98d78e5e5cb6c85f72204fd46feb9f76853841d4a701add36c0008ba0cb2fad622697145cf1665124096d25bc31ef44e0af44e00b960000008ba0cb2fad622697145cf1665124096d25bc31e00"
+
+	for _, kind := range []ECKind{Ed25519, Secp256k1, NistP256} {
+		t.Run(string(kind), func(t *testing.T) {
+			key, err := Generate(kind)
+			testutils.CheckErr(t, false, "", err)
+
+			sig, err := key.SignHex(msg)
+			testutils.CheckErr(t, false, "", err)
+
+			ok, err := key.PubKey.VerifyHex(msg, sig.ToBase58())
+			testutils.CheckErr(t, false, "", err)
+			assert.True(t, ok)
+
+			// The generic watermark is implied, so an already watermarked message verifies too.
+			ok, err = key.PubKey.VerifyHex("03"+msg, sig.ToBase58())
+			testutils.CheckErr(t, false, "", err)
+			assert.True(t, ok)
+
+			ok, err = key.PubKey.VerifyHex(msg, tzcrypt.B58cencode(sig.Bytes, genericSignaturePrefix))
+			testutils.CheckErr(t, false, "", err)
+			assert.True(t, ok)
+
+			ok, err = key.PubKey.VerifyHex(msg+"00", sig.ToBase58())
+			testutils.CheckErr(t, false, "", err)
+			assert.False(t, ok)
+
+			for _, other := range []ECKind{Ed25519, Secp256k1, NistP256} {
+				if other == kind {
+					continue
+				}
+
+				mismatched := Signature{Bytes: sig.Bytes, Curve: other}
+				_, err = key.PubKey.VerifyHex(msg, mismatched.ToBase58())
+				testutils.CheckErr(t, true, fmt.Sprintf("failed to verify signature: %s signature for %s public key", other, kind), err)
+			}
+
+			other, err := Generate(kind)
+			testutils.CheckErr(t, false, "", err)
+
+			ok, err = other.PubKey.Verify([]byte("message"), sig)
+			testutils.CheckErr(t, false, "", err)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func Test_VerifyHex(t *testing.T) {
+	key, err := FromHex("7579c4881fb998d043417b7c04582aa15179f125c5303e1ee56a9678034d95b0", Ed25519)
+	testutils.CheckErr(t, false, "", err)
+
+	msg := "a732d3520eeaa3de98d78e5e5cb6c85f72204fd46feb9f76853841d4a701add36c0008ba0cb2fad622697145cf1665124096d25bc31ef44e0af44e00b960000008ba0cb2fad622697145cf1665124096d25bc31e00"
+	for _, sig := range []string{
+		"edsigtyo7bF9fBTM8Ltn4MdbhYfifVq8cCnh1ade7XRM9mAxCNQnDtQQpdgsJWGBuXP3cFj7U19evJVGKezPxxc4Kqtr5SmgA4U",
+		"sigoyeUBvkuXA6HhnmDbkWbNS9j13gE9pRCwtNo5MAkpwF4aqdPWVjYddPTfa7RusFpBdr5dsZTsJYvCMmJTiMeqmyaDp3cq",
+	} {
+		ok, err := key.PubKey.VerifyHex(msg, sig)
+		testutils.CheckErr(t, false, "", err)
+		assert.True(t, ok)
+	}
+
+	_, err = key.PubKey.VerifyHex("zz", "edsigtyo7bF9fBTM8Ltn4MdbhYfifVq8cCnh1ade7XRM9mAxCNQnDtQQpdgsJWGBuXP3cFj7U19evJVGKezPxxc4Kqtr5SmgA4U")
+	testutils.CheckErr(t, true, "failed to hex decode message", err)
+}
+
+func Test_SignatureFromBase58(t *testing.T) {
+	cases := []struct {
+		name        string
+		input       string
+		wantErr     bool
+		containsErr string
+	}{
+		{
+			"is successful with edsig",
+			"edsigtyo7bF9fBTM8Ltn4MdbhYfifVq8cCnh1ade7XRM9mAxCNQnDtQQpdgsJWGBuXP3cFj7U19evJVGKezPxxc4Kqtr5SmgA4U",
+			false,
+			"",
+		},
+		{
+			"is successful with sig",
+			"sigoyeUBvkuXA6HhnmDbkWbNS9j13gE9pRCwtNo5MAkpwF4aqdPWVjYddPTfa7RusFpBdr5dsZTsJYvCMmJTiMeqmyaDp3cq",
+			false,
+			"",
+		},
+		{
+			"handles invalid checksum",
+			"edsigtyo7bF9fBTM8Ltn4MdbhYfifVq8cCnh1ade7XRM9mAxCNQnDtQQpdgsJWGBuXP3cFj7U19evJVGKezPxxc4Kqtr5SmgA4V",
+			true,
+			"failed to import signature",
+		},
+		{
+			"handles unknown prefix",
+			"tz1L8fUQLuwRuywTZUP5JUw9LL3kJa8LMfoo",
+			true,
+			"unknown prefix",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			sig, err := SignatureFromBase58(tt.input)
+			testutils.CheckErr(t, tt.wantErr, tt.containsErr, err)
+			if !tt.wantErr {
+				assert.Equal(t, tt.input, sig.ToBase58())
+				assert.Len(t, sig.Bytes, 64)
+			}
+		})
+	}
+}
+
+func Test_PubKeyFromBase58(t *testing.T) {
+	type want struct {
+		err         bool
+		errContains string
+		address     string
+	}
+
+	offCurve := append([]byte{2}, make([]byte, 31)...)
+	cases := []struct {
+		name  string
+		input string
+		want  want
+	}{
+		{"is successful with edpk", "edpkvS5QFv7KRGfa3b87gg9DBpxSm3NpSwnjhUjNBQrRUUR66F7C9g", want{false, "", "tz1KiAZmm1ShNEs38kxs5zk4AyLo2XssfTsT"}},
+		{"is successful with sppk", "sppk7ZZADMMS4cwsu3odb7BAu9mx3DZYHmXWWL9GNhKremaJXqytGBc", want{false, "", "tz2TUwYWy5VP7ChX2xjXtGxxdfCnEQsotdeQ"}},
+		{"is successful with p2pk", "p2pk6594Hd4VEVPydvK67c2GVikNXWjLiv2tkPUVvd8XMAXqd4CYxdK", want{false, "", "tz3fU9apdFnzoPhi4LB8AdxoiSVwLYM4kQ1F"}},
+		{"handles invalid checksum", "edpkvS5QFv7KRGfa3b87gg9DBpxSm3NpSwnjhUjNBQrRUUR66F7C9h", want{true, "failed to import pub key: invalid public key", ""}},
+		{"handles secp256k1 point off the curve", tzcrypt.B58cencode(append(offCurve, 5), (&secp256k1Curve{}).publicKeyPrefix()), want{true, "failed to import pub key: public key is not on the curve", ""}},
+		{"handles p256 point off the curve", tzcrypt.B58cencode(append(offCurve, 1), (&nistP256Curve{}).publicKeyPrefix()), want{true, "failed to import pub key: public key is not on the curve", ""}},
+		{"handles uncompressed point", tzcrypt.B58cencode(append([]byte{4}, make([]byte, 32)...), (&secp256k1Curve{}).publicKeyPrefix()), want{true, "not a compressed point", ""}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			pubKey, err := PubKeyFromBase58(tt.input)
+			testutils.CheckErr(t, tt.want.err, tt.want.errContains, err)
+			assert.Equal(t, tt.want.address, pubKey.GetAddress())
+			if !tt.want.err {
+				assert.Equal(t, tt.input, pubKey.GetPublicKey())
+			}
+		})
+	}
+}
+
+func Test_PubKeyFromBytes(t *testing.T) {
+	for _, kind := range []ECKind{Ed25519, Secp256k1, NistP256} {
+		t.Run(string(kind), func(t *testing.T) {
+			key, err := Generate(kind)
+			testutils.CheckErr(t, false, "", err)
+
+			pubKey, err := PubKeyFromBytes(key.PubKey.GetBytes(), kind)
+			testutils.CheckErr(t, false, "", err)
+			assert.Equal(t, key.PubKey.GetAddress(), pubKey.GetAddress())
+			assert.Equal(t, key.PubKey.GetPublicKey(), pubKey.GetPublicKey())
+
+			sig, err := key.SignBytes([]byte("message"))
+			testutils.CheckErr(t, false, "", err)
+
+			ok, err := pubKey.Verify([]byte("message"), sig)
+			testutils.CheckErr(t, false, "", err)
+			assert.True(t, ok)
+
+			_, err = PubKeyFromBytes(key.PubKey.GetBytes()[1:], kind)
+			testutils.CheckErr(t, true, "failed to import pub key", err)
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package keys
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	tzcrypt "github.com/goat-systems/go-tezos/v4/internal/crypto"
+	"github.com/pkg/errors"
+)
+
+/*
+RemoteSigner signs with a key held by a remote signer speaking the tezos-signer HTTP protocol, such as
+tezos-signer or a hardware wallet behind it. It signs the same way Key does, so it can be used wherever SignHex or
+SignBytes is.
+*/
+type RemoteSigner struct {
+	client         *resty.Client
+	host           string
+	authentication *Key
+	PubKey         PubKey
+}
+
+/*
+NewRemoteSigner returns a RemoteSigner for the key with the public key hash pkh on the signer at host, and
+fetches the key's public key.
+
+Path:
+	GET /keys/<pkh>
+
+Example:
+	signer, err := keys.NewRemoteSigner("http://127.0.0.1:6732", "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc")
+*/
+func NewRemoteSigner(host, pkh string) (*RemoteSigner, error) {
+	r := &RemoteSigner{
+		client: resty.New(),
+		host:   cleanseHost(host),
+	}
+
+	var resp struct {
+		PublicKey string `json:"public_key"`
+	}
+	if err := r.get(fmt.Sprintf("/keys/%s", pkh), &resp); err != nil {
+		return nil, errors.Wrapf(err, "failed to get public key of '%s'", pkh)
+	}
+
+	pubKey, err := PubKeyFromBase58(resp.PublicKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get public key of '%s'", pkh)
+	}
+
+	if pubKey.GetAddress() != pkh {
+		return nil, errors.Errorf("failed to get public key of '%s': signer returned the public key of '%s'", pkh, pubKey.GetAddress())
+	}
+	r.PubKey = pubKey
+
+	return r, nil
+}
+
+/*
+SetAuthenticationKey sets the key requests to a signer requiring authentication are signed with. It must be one
+of the signer's authorized keys (see AuthorizedKeys).
+*/
+func (r *RemoteSigner) SetAuthenticationKey(key *Key) {
+	r.authentication = key
+}
+
+/*
+OverrideClient overrides underlying network client.
+Can allow you to create middleware as needed: https://github.com/go-resty/resty#request-and-response-middleware
+*/
+func (r *RemoteSigner) OverrideClient(client *resty.Client) {
+	r.client = client
+}
+
+/*
+AuthorizedKeys returns the public key hashes of the keys the signer accepts authenticated requests from. It
+returns nil if the signer does not require authentication.
+
+Path:
+	GET /authorized_keys
+*/
+func (r *RemoteSigner) AuthorizedKeys() ([]string, error) {
+	var resp struct {
+		AuthorizedKeys []string `json:"authorized_keys"`
+	}
+	if err := r.get("/authorized_keys", &resp); err != nil {
+		return nil, errors.Wrap(err, "failed to get authorized keys")
+	}
+
+	return resp.AuthorizedKeys, nil
+}
+
+// SignHex will sign a hex encoded generic operation with the remote key, see SignBytes
+func (r *RemoteSigner) SignHex(msg string) (Signature, error) {
+	bytes, err := hex.DecodeString(msg)
+	if err != nil {
+		return Signature{}, errors.Wrap(err, "failed to hex decode message")
+	}
+
+	return r.SignBytes(bytes)
+}
+
+/*
+SignBytes will sign a generic operation with the remote key, watermarked the same way Key.SignBytes does. The
+signature is checked against the key's public key before it is returned.
+
+Path:
+	POST /keys/<pkh>
+*/
+func (r *RemoteSigner) SignBytes(msg []byte) (Signature, error) {
+	return r.sign(checkAndAddWaterMark(msg))
+}
+
+// sign signs the watermarked msg with the remote key
+func (r *RemoteSigner) sign(msg []byte) (Signature, error) {
+	req := r.client.R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(fmt.Sprintf(`"%s"`, hex.EncodeToString(msg)))
+
+	if r.authentication != nil {
+		authentication, err := r.authenticate(msg)
+		if err != nil {
+			return Signature{}, errors.Wrap(err, "failed to sign with remote signer")
+		}
+		req.SetQueryParam("authentication", authentication)
+	}
+
+	var resp struct {
+		Signature string `json:"signature"`
+	}
+	res, err := req.SetResult(&resp).Post(fmt.Sprintf("%s/keys/%s", r.host, r.PubKey.GetAddress()))
+	if err != nil {
+		return Signature{}, errors.Wrap(err, "failed to sign with remote signer")
+	}
+
+	if res.IsError() {
+		return Signature{}, errors.Errorf("failed to sign with remote signer: %s", describeResponse(res))
+	}
+
+	signature, err := SignatureFromBase58(resp.Signature)
+	if err != nil {
+		return Signature{}, errors.Wrap(err, "failed to sign with remote signer")
+	}
+
+	ok, err := r.PubKey.verify(msg, signature)
+	if err != nil {
+		return Signature{}, errors.Wrap(err, "failed to sign with remote signer")
+	}
+
+	if !ok {
+		return Signature{}, errors.New("failed to sign with remote signer: signature does not match public key")
+	}
+
+	return signature, nil
+}
+
+/*
+authenticate signs a request to sign msg with the authentication key. The signer expects a signature of the byte
+0x04, the binary public key hash of the signing key and msg, without watermark.
+*/
+func (r *RemoteSigner) authenticate(msg []byte) (string, error) {
+	pkh, err := forgePublicKeyHash(r.PubKey)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to authenticate request")
+	}
+
+	data := append(append([]byte{4}, pkh...), msg...)
+	signature, err := r.authentication.curve.sign(data, r.authentication.privKey)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to authenticate request")
+	}
+
+	return signature.ToBase58(), nil
+}
+
+func (r *RemoteSigner) get(path string, result interface{}) error {
+	resp, err := r.client.R().SetResult(result).Get(fmt.Sprintf("%s%s", r.host, path))
+	if err != nil {
+		return err
+	}
+
+	if resp.IsError() {
+		return errors.New(describeResponse(resp))
+	}
+
+	return nil
+}
+
+// forgePublicKeyHash returns the binary encoding of a public key's hash: a curve tag followed by the 20 byte hash
+func forgePublicKeyHash(pubKey PubKey) ([]byte, error) {
+	var tag byte
+	switch pubKey.curve.getECKind() {
+	case Ed25519:
+		tag = 0
+	case Secp256k1:
+		tag = 1
+	case NistP256:
+		tag = 2
+	}
+
+	hash, err := tzcrypt.Decode(pubKey.GetAddress())
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte{tag}, hash[len(pubKey.curve.addressPrefix()):]...), nil
+}
+
+func describeResponse(resp *resty.Response) string {
+	return fmt.Sprintf("signer responded with status %d: %s", resp.StatusCode(), strings.TrimSpace(string(resp.Body())))
+}
+
+func cleanseHost(host string) string {
+	host = strings.TrimSuffix(host, "/")
+	if !strings.HasPrefix(host, "http://") && !strings.HasPrefix(host, "https://") {
+		host = fmt.Sprintf("http://%s", host)
+	}
+
+	return host
+}
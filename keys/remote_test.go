@@ -0,0 +1,155 @@
+package keys
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/goat-systems/go-tezos/v4/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/blake2b"
+)
+
+// signerMock stands in for a tezos-signer holding key, which only accepts requests authenticated by authorized
+// if it is set
+func signerMock(key *Key, authorized *Key) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.URL.Path == "/authorized_keys":
+			if authorized == nil {
+				fmt.Fprint(w, `{}`)
+				return
+			}
+			fmt.Fprintf(w, `{"authorized_keys":["%s"]}`, authorized.PubKey.GetAddress())
+		case r.URL.Path != fmt.Sprintf("/keys/%s", key.PubKey.GetAddress()):
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `[{"kind":"temporary","id":"failure","msg":"no keys for the source contract manager"}]`)
+		case r.Method == http.MethodGet:
+			fmt.Fprintf(w, `{"public_key":"%s"}`, key.PubKey.GetPublicKey())
+		case r.Method == http.MethodPost:
+			var data string
+			json.NewDecoder(r.Body).Decode(&data)
+			msg, _ := hex.DecodeString(data)
+
+			if authorized != nil {
+				signature, err := SignatureFromBase58(r.URL.Query().Get("authentication"))
+				if err != nil {
+					w.WriteHeader(http.StatusInternalServerError)
+					fmt.Fprint(w, `[{"kind":"temporary","id":"failure","msg":"missing authentication signature field"}]`)
+					return
+				}
+
+				pkh, _ := forgePublicKeyHash(key.PubKey)
+				hash := blake2b.Sum256(append(append([]byte{4}, pkh...), msg...))
+				if ok, _ := authorized.curve.verify(hash[:], signature.Bytes, authorized.PubKey.pubKey); !ok {
+					w.WriteHeader(http.StatusInternalServerError)
+					fmt.Fprint(w, `[{"kind":"temporary","id":"failure","msg":"invalid authentication signature"}]`)
+					return
+				}
+			}
+
+			signature, _ := key.curve.sign(msg, key.privKey)
+			fmt.Fprintf(w, `{"signature":"%s"}`, signature.ToBase58())
+		}
+	})
+}
+
+func Test_RemoteSigner(t *testing.T) {
+	key, err := FromBase58("edskRsPBsKuULoLTEQV2R9UbvSZbzFqvoESvp1mYyQJU8xi9mJamt88r5uTXbWQpVHjSiPWWtnoyqTCuSLQLxbEKUXfwwTccsF", Ed25519)
+	testutils.CheckErr(t, false, "", err)
+
+	authorized, err := FromBase58("spsk2psNeAQ88pKFnZikoZNb37zRbDmaGgQUtYrwwJZT3RcUspwL7N", Secp256k1)
+	testutils.CheckErr(t, false, "", err)
+
+	msg := "a732d3520eeaa3de98d78e5e5cb6c85f72204fd46feb9f76853841d4a701add36c0008ba0cb2fad622697145cf1665124096d25bc31ef44e0af44e00b960000008ba0cb2fad622697145cf1665124096d25bc31e00"
+
+	t.Run("signs like an in memory key", func(t *testing.T) {
+		server := httptest.NewServer(signerMock(key, nil))
+		defer server.Close()
+
+		signer, err := NewRemoteSigner(server.URL, key.PubKey.GetAddress())
+		testutils.CheckErr(t, false, "", err)
+		assert.Equal(t, key.PubKey.GetPublicKey(), signer.PubKey.GetPublicKey())
+
+		signature, err := signer.SignHex(msg)
+		testutils.CheckErr(t, false, "", err)
+
+		// ed25519 signatures are deterministic
+		want, err := key.SignHex(msg)
+		testutils.CheckErr(t, false, "", err)
+		assert.Equal(t, want.ToBase58(), signature.ToBase58())
+
+		authorizedKeys, err := signer.AuthorizedKeys()
+		testutils.CheckErr(t, false, "", err)
+		assert.Nil(t, authorizedKeys)
+	})
+
+	t.Run("authenticates requests", func(t *testing.T) {
+		server := httptest.NewServer(signerMock(key, authorized))
+		defer server.Close()
+
+		signer, err := NewRemoteSigner(server.URL, key.PubKey.GetAddress())
+		testutils.CheckErr(t, false, "", err)
+
+		authorizedKeys, err := signer.AuthorizedKeys()
+		testutils.CheckErr(t, false, "", err)
+		assert.Equal(t, []string{authorized.PubKey.GetAddress()}, authorizedKeys)
+
+		_, err = signer.SignHex(msg)
+		testutils.CheckErr(t, true, "missing authentication signature field", err)
+
+		signer.SetAuthenticationKey(authorized)
+		signature, err := signer.SignHex(msg)
+		testutils.CheckErr(t, false, "", err)
+		assert.True(t, strings.HasPrefix(signature.ToBase58(), "edsig"))
+
+		signer.SetAuthenticationKey(key)
+		_, err = signer.SignHex(msg)
+		testutils.CheckErr(t, true, "invalid authentication signature", err)
+	})
+
+	t.Run("handles unknown key", func(t *testing.T) {
+		server := httptest.NewServer(signerMock(key, nil))
+		defer server.Close()
+
+		_, err := NewRemoteSigner(server.URL, authorized.PubKey.GetAddress())
+		testutils.CheckErr(t, true, "signer responded with status 404", err)
+	})
+
+	other, err := Generate(Ed25519)
+	testutils.CheckErr(t, false, "", err)
+
+	for _, tt := range []struct {
+		name        string
+		signer      *Key
+		containsErr string
+	}{
+		{"handles signature of another key", other, "signature does not match public key"},
+		{"handles signature of another curve", authorized, "failed to verify signature: Secp256k1 signature for Ed25519 public key"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPost {
+					w.Header().Set("Content-Type", "application/json")
+					signature, _ := tt.signer.SignHex(msg)
+					fmt.Fprintf(w, `{"signature":"%s"}`, signature.ToBase58())
+					return
+				}
+				signerMock(key, nil).ServeHTTP(w, r)
+			}))
+			defer server.Close()
+
+			signer, err := NewRemoteSigner(server.URL, key.PubKey.GetAddress())
+			testutils.CheckErr(t, false, "", err)
+
+			_, err = signer.SignHex(msg)
+			testutils.CheckErr(t, true, tt.containsErr, err)
+		})
+	}
+}
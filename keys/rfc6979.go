@@ -0,0 +1,85 @@
+package keys
+
+import (
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha256"
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+/*
+signRFC6979 signs hash with the private key d using a nonce derived from d and hash, as described in RFC 6979
+with HMAC-SHA256. Signing the same hash with the same key always gives the same signature, which is what octez and
+hardware signers do for secp256k1 and P256. See https://tools.ietf.org/html/rfc6979#section-3.2.
+*/
+func signRFC6979(curve elliptic.Curve, d *big.Int, hash []byte) (*big.Int, *big.Int, error) {
+	q := curve.Params().N
+	if d.Sign() <= 0 || d.Cmp(q) >= 0 {
+		return nil, nil, errors.New("invalid private key")
+	}
+
+	qlen := q.BitLen()
+	rlen := (qlen + 7) / 8
+
+	z := bits2int(hash, qlen)
+	x := leftPad(d.Bytes(), rlen)
+	h1 := leftPad(new(big.Int).Mod(z, q).Bytes(), rlen)
+
+	v := make([]byte, sha256.Size)
+	for i := range v {
+		v[i] = 1
+	}
+	k := make([]byte, sha256.Size)
+
+	k = mac(k, v, []byte{0}, x, h1)
+	v = mac(k, v)
+	k = mac(k, v, []byte{1}, x, h1)
+	v = mac(k, v)
+
+	for {
+		var t []byte
+		for len(t) < rlen {
+			v = mac(k, v)
+			t = append(t, v...)
+		}
+
+		nonce := bits2int(t[:rlen], qlen)
+		if nonce.Sign() > 0 && nonce.Cmp(q) < 0 {
+			rx, _ := curve.ScalarBaseMult(leftPad(nonce.Bytes(), rlen))
+			r := new(big.Int).Mod(rx, q)
+
+			s := new(big.Int).Mul(r, d)
+			s.Add(s, z)
+			s.Mul(s, new(big.Int).ModInverse(nonce, q))
+			s.Mod(s, q)
+
+			if r.Sign() != 0 && s.Sign() != 0 {
+				return r, s, nil
+			}
+		}
+
+		k = mac(k, v, []byte{0})
+		v = mac(k, v)
+	}
+}
+
+// bits2int converts the leftmost qlen bits of v to an integer
+func bits2int(v []byte, qlen int) *big.Int {
+	i := new(big.Int).SetBytes(v)
+	if blen := len(v) * 8; blen > qlen {
+		i.Rsh(i, uint(blen-qlen))
+	}
+
+	return i
+}
+
+func mac(key []byte, data ...[]byte) []byte {
+	h := hmac.New(sha256.New, key)
+	for _, d := range data {
+		h.Write(d)
+	}
+
+	return h.Sum(nil)
+}
@@ -0,0 +1,68 @@
+package keys
+
+import (
+	"crypto/elliptic"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/goat-systems/go-tezos/v4/internal/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_signRFC6979(t *testing.T) {
+	hexInt := func(v string) *big.Int {
+		i, _ := new(big.Int).SetString(v, 16)
+		return i
+	}
+
+	cases := []struct {
+		name  string
+		curve elliptic.Curve
+		d     *big.Int
+		msg   string
+		r     *big.Int
+		s     *big.Int
+	}{
+		{
+			// https://tools.ietf.org/html/rfc6979#appendix-A.2.5
+			"P256 with sample",
+			elliptic.P256(),
+			hexInt("C9AFA9D845BA75166B5C215767B1D6934E50C3DB36E89B127B8A622B120F6721"),
+			"sample",
+			hexInt("EFD48B2AACB6A8FD1140DD9CD45E81D69D2C877B56AAF991C34D0EA84EAF3716"),
+			hexInt("F7CB1C942D657C41D436C7A1B6E29F65F3E900DBB9AFF4064DC4AB2F843ACDA8"),
+		},
+		{
+			// https://tools.ietf.org/html/rfc6979#appendix-A.2.5
+			"P256 with test",
+			elliptic.P256(),
+			hexInt("C9AFA9D845BA75166B5C215767B1D6934E50C3DB36E89B127B8A622B120F6721"),
+			"test",
+			hexInt("F1ABB023518351CD71D881567B1EA663ED3EFCF6C5132B354F28D3B0B7D38367"),
+			hexInt("019F4113742A2B14BD25926B49C649155F267E60D3814B4C0CC84250E46F0083"),
+		},
+		{
+			"secp256k1 with Satoshi Nakamoto",
+			ethcrypto.S256(),
+			big.NewInt(1),
+			"Satoshi Nakamoto",
+			hexInt("934B1EA10A4B3C1757E2B0C017D0B6143CE3C9A7E6A4A49860D7A6AB210EE3D8"),
+			hexInt("DBBD3162D46E9F9BEF7FEB87C16DC13B4F6568A87F4E83F728E2443BA586675C"),
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			hash := sha256.Sum256([]byte(tt.msg))
+			r, s, err := signRFC6979(tt.curve, tt.d, hash[:])
+			testutils.CheckErr(t, false, "", err)
+			assert.Equal(t, tt.r, r)
+			assert.Equal(t, tt.s, s)
+		})
+	}
+
+	_, _, err := signRFC6979(elliptic.P256(), big.NewInt(0), make([]byte, 32))
+	testutils.CheckErr(t, true, "invalid private key", err)
+}
@@ -1,8 +1,6 @@
 package keys
 
 import (
-	"crypto/ecdsa"
-	"crypto/rand"
 	"math/big"
 
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
@@ -36,6 +34,10 @@ func (s *secp256k1Curve) privateKeyPrefix() []byte {
 	return []byte{17, 162, 224, 201}
 }
 
+func (s *secp256k1Curve) encryptedPrivateKeyPrefix() []byte {
+	return []byte{9, 237, 241, 174, 150}
+}
+
 func (s *secp256k1Curve) signaturePrefix() []byte {
 	return []byte{13, 115, 101, 19, 63}
 }
@@ -68,6 +70,18 @@ func (s *secp256k1Curve) getPublicKey(privateKey []byte) ([]byte, error) {
 	return append(pref, pad[len(pad)-32:]...), nil
 }
 
+func (s *secp256k1Curve) validatePublicKey(publicKey []byte) error {
+	if len(publicKey) != 33 || (publicKey[0] != 2 && publicKey[0] != 3) {
+		return errors.New("public key is not a compressed point")
+	}
+
+	if _, err := ethcrypto.DecompressPubkey(publicKey); err != nil {
+		return errors.New("public key is not on the curve")
+	}
+
+	return nil
+}
+
 func (s *secp256k1Curve) sign(msg []byte, privateKey []byte) (Signature, error) {
 	hash, err := blake2b.New(32, []byte{})
 	if err != nil {
@@ -87,7 +101,7 @@ func (s *secp256k1Curve) sign(msg []byte, privateKey []byte) (Signature, error)
 		return Signature{}, err
 	}
 
-	r, ss, err := ecdsa.Sign(rand.Reader, privKey, hash.Sum([]byte{}))
+	r, ss, err := signRFC6979(privKey.Curve, privKey.D, hash.Sum([]byte{}))
 	if err != nil {
 		return Signature{}, err
 	}
@@ -96,9 +110,21 @@ func (s *secp256k1Curve) sign(msg []byte, privateKey []byte) (Signature, error)
 		ss = big.NewInt(0).Sub(order(), ss)
 	}
 
-	signature := append(r.Bytes(), ss.Bytes()...)
+	signature := append(leftPad(r.Bytes(), 32), leftPad(ss.Bytes(), 32)...)
 	return Signature{
-		Bytes:  signature,
-		prefix: s.signaturePrefix(),
+		Bytes: signature,
+		Curve: s.getECKind(),
 	}, nil
 }
+
+func (s *secp256k1Curve) verify(hash []byte, signature []byte, publicKey []byte) (bool, error) {
+	if len(signature) != 64 {
+		return false, errors.Errorf("invalid signature length %d", len(signature))
+	}
+	if _, err := ethcrypto.DecompressPubkey(publicKey); err != nil {
+		return false, errors.Wrap(err, "invalid public key")
+	}
+
+	// VerifySignature rejects signatures with a high S value just as the node does.
+	return ethcrypto.VerifySignature(publicKey, hash, signature), nil
+}
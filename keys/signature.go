@@ -5,12 +5,37 @@ import (
 	"fmt"
 
 	"github.com/goat-systems/go-tezos/v4/internal/crypto"
+	"github.com/goat-systems/go-tezos/v4/tezos"
+	"github.com/pkg/errors"
 )
 
-// Signature represents the signature of an operation
+// genericSignaturePrefix is the prefix of a signature that is not tied to a curve (sig).
+var genericSignaturePrefix = []byte{4, 130, 43}
+
+/*
+Signature represents the signature of an operation. Signers other than Key and RemoteSigner return the raw 64 byte
+signature along with the curve of their key, which is all ToBase58 and the forge and inject path need.
+*/
 type Signature struct {
-	Bytes  []byte
-	prefix []byte
+	Bytes []byte
+	// The curve of the key that made the signature, empty for a generic signature (sig).
+	Curve ECKind
+}
+
+/*
+SignatureFromBase58 returns a signature from its base58 encoded form. Curve specific (edsig, spsig1, p2sig)
+and generic (sig) signatures are supported.
+*/
+func SignatureFromBase58(sig string) (Signature, error) {
+	v, err := tezos.ParseSignature(sig)
+	if err != nil {
+		return Signature{}, errors.Wrap(err, "failed to import signature")
+	}
+
+	return Signature{
+		Bytes: v.Bytes(),
+		Curve: v.Curve(),
+	}, nil
 }
 
 // ToBytes returns the signature as bytes
@@ -20,7 +45,11 @@ func (s *Signature) ToBytes() []byte {
 
 // ToBase58 returns the signature as a base58 encoded string with the correct prefix
 func (s *Signature) ToBase58() string {
-	return crypto.B58cencode(s.Bytes, s.prefix)
+	if s.Curve == "" {
+		return crypto.B58cencode(s.Bytes, genericSignaturePrefix)
+	}
+
+	return crypto.B58cencode(s.Bytes, getCurve(s.Curve).signaturePrefix())
 }
 
 // ToHex returns the signature encoded to hex
@@ -0,0 +1,64 @@
+package keys
+
+/*
+Signer is anything that can sign for a Tezos account: in memory keys (Key), remote signers (RemoteSigner), or
+implementations of your own backed by a KMS, an HSM or a multisig coordinator. The operation package signs with a
+Signer, so any implementation can build and inject operations.
+
+Implementations hash the watermarked message with blake2b-256 and sign the hash with the account's key, and return
+the raw 64 byte signature along with the curve of the key (see Signature).
+*/
+type Signer interface {
+	// PublicKey returns the public key of the account.
+	PublicKey() PubKey
+	// Address returns the public key hash (tz1, tz2 or tz3) of the account.
+	Address() string
+	// Sign signs msg preceded by watermark.
+	Sign(msg []byte, watermark Watermark) (Signature, error)
+}
+
+var (
+	_ Signer = &Key{}
+	_ Signer = &RemoteSigner{}
+)
+
+// PublicKey returns the public key of the key.
+func (k *Key) PublicKey() PubKey {
+	return k.PubKey
+}
+
+// Address returns the public key hash (tz1, tz2 or tz3) of the key.
+func (k *Key) Address() string {
+	return k.PubKey.GetAddress()
+}
+
+/*
+Sign signs msg preceded by watermark.
+
+Example:
+	signature, err := key.Sign(forged, keys.GenericOperationWatermark)
+*/
+func (k *Key) Sign(msg []byte, watermark Watermark) (Signature, error) {
+	return k.curve.sign(watermark.watermark(msg), k.privKey)
+}
+
+// PublicKey returns the public key of the remote key.
+func (r *RemoteSigner) PublicKey() PubKey {
+	return r.PubKey
+}
+
+// Address returns the public key hash (tz1, tz2 or tz3) of the remote key.
+func (r *RemoteSigner) Address() string {
+	return r.PubKey.GetAddress()
+}
+
+/*
+Sign signs msg preceded by watermark with the remote key. The signer may refuse to sign watermarks it isn't
+configured to sign, such as blocks and endorsements.
+
+Path:
+	POST /keys/<pkh>
+*/
+func (r *RemoteSigner) Sign(msg []byte, watermark Watermark) (Signature, error) {
+	return r.sign(watermark.watermark(msg))
+}
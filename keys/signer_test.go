@@ -0,0 +1,83 @@
+package keys
+
+import (
+	"encoding/hex"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/goat-systems/go-tezos/v4/internal/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Sign(t *testing.T) {
+	msg, err := hex.DecodeString("a732d3520eeaa3de98d78e5e5cb6c85f72204fd46feb9f76853841d4a701add36c0008ba0cb2fad622697145cf1665124096d25bc31ef44e0af44e00b960000008ba0cb2fad622697145cf1665124096d25bc31e00")
+	testutils.CheckErr(t, false, "", err)
+
+	for _, kind := range []ECKind{Ed25519, Secp256k1, NistP256} {
+		t.Run(string(kind), func(t *testing.T) {
+			key, err := Generate(kind)
+			testutils.CheckErr(t, false, "", err)
+
+			var signer Signer = key
+			assert.Equal(t, key.PubKey.GetAddress(), signer.Address())
+			pubKey := signer.PublicKey()
+			assert.Equal(t, key.PubKey.GetPublicKey(), pubKey.GetPublicKey())
+
+			signature, err := signer.Sign(msg, GenericOperationWatermark)
+			testutils.CheckErr(t, false, "", err)
+			assert.Equal(t, kind, signature.Curve)
+			assert.Equal(t, byte(0xa7), msg[0], "the message must not be modified")
+
+			ok, err := key.PubKey.Verify(msg, signature)
+			testutils.CheckErr(t, false, "", err)
+			assert.True(t, ok)
+
+			server := httptest.NewServer(signerMock(key, nil))
+			defer server.Close()
+
+			signer, err = NewRemoteSigner(server.URL, key.PubKey.GetAddress())
+			testutils.CheckErr(t, false, "", err)
+			assert.Equal(t, key.PubKey.GetAddress(), signer.Address())
+
+			remote, err := signer.Sign(msg, GenericOperationWatermark)
+			testutils.CheckErr(t, false, "", err)
+			assert.Equal(t, signature.Curve, remote.Curve)
+
+			ok, err = key.PubKey.Verify(msg, remote)
+			testutils.CheckErr(t, false, "", err)
+			assert.True(t, ok)
+
+			watermark, err := NewWatermark(WatermarkTenderbakeBlock, "NetXdQprcVkpaWU")
+			testutils.CheckErr(t, false, "", err)
+
+			remote, err = signer.Sign(msg, watermark)
+			testutils.CheckErr(t, false, "", err)
+
+			ok, err = key.PubKey.VerifyWithWatermark(msg, watermark, remote)
+			testutils.CheckErr(t, false, "", err)
+			assert.True(t, ok)
+		})
+	}
+}
+
+func Test_Signature_ToBase58(t *testing.T) {
+	cases := []struct {
+		curve  ECKind
+		prefix string
+	}{
+		{Ed25519, "edsig"},
+		{Secp256k1, "spsig1"},
+		{NistP256, "p2sig"},
+		{"", "sig"},
+	}
+
+	for _, tt := range cases {
+		signature := Signature{Bytes: make([]byte, 64), Curve: tt.curve}
+		assert.True(t, strings.HasPrefix(signature.ToBase58(), tt.prefix))
+
+		parsed, err := SignatureFromBase58(signature.ToBase58())
+		testutils.CheckErr(t, false, "", err)
+		assert.Equal(t, signature, parsed)
+	}
+}
@@ -0,0 +1,344 @@
+package keys
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// The files octez-client (tezos-client) keeps its wallet in, relative to its base directory
+const (
+	secretKeysFile      = "secret_keys"
+	publicKeysFile      = "public_keys"
+	publicKeyHashesFile = "public_key_hashs"
+	configFile          = "config"
+)
+
+// DefaultWalletDir is the base directory octez-client uses when none is given, relative to the home directory.
+const DefaultWalletDir = ".tezos-client"
+
+// PasswordFunc returns the password of the encrypted secret key of alias, typically by prompting the user for it.
+type PasswordFunc func(alias string) (string, error)
+
+/*
+Wallet is the wallet of an octez-client base directory: the secret keys, public keys and public key hashes it
+knows by alias. Secret keys are only decrypted when asked for, and remote keys are exposed as the remote signer
+endpoint holding them.
+*/
+type Wallet struct {
+	secretKeys      map[string]string
+	publicKeys      map[string]string
+	publicKeyHashes map[string]string
+	remoteSigner    string
+	password        PasswordFunc
+}
+
+// walletEntry is an entry of one of the wallet files
+type walletEntry struct {
+	Name  string          `json:"name"`
+	Value json.RawMessage `json:"value"`
+}
+
+/*
+LoadWallet reads the wallet of an octez-client base directory, such as ~/.tezos-client.
+
+Parameters:
+
+	dir:
+		The base directory of octez-client (see DefaultWalletDir).
+
+	password:
+		Called for the password of an encrypted secret key whenever one is needed. It may be nil if the wallet has
+		no encrypted keys.
+
+Example:
+	wallet, err := keys.LoadWallet("/home/tezos/.tezos-client", func(alias string) (string, error) {
+		fmt.Printf("Enter password for encrypted key %s: ", alias)
+		passwd, err := terminal.ReadPassword(int(os.Stdin.Fd()))
+		return string(passwd), err
+	})
+*/
+func LoadWallet(dir string, password PasswordFunc) (*Wallet, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, errors.Wrap(err, "failed to load wallet")
+	}
+
+	w := &Wallet{
+		secretKeys:      map[string]string{},
+		publicKeys:      map[string]string{},
+		publicKeyHashes: map[string]string{},
+		password:        password,
+	}
+
+	if err := readWalletFile(filepath.Join(dir, secretKeysFile), w.secretKeys, stringValue); err != nil {
+		return nil, errors.Wrap(err, "failed to load wallet")
+	}
+
+	if err := readWalletFile(filepath.Join(dir, publicKeysFile), w.publicKeys, publicKeyValue); err != nil {
+		return nil, errors.Wrap(err, "failed to load wallet")
+	}
+
+	if err := readWalletFile(filepath.Join(dir, publicKeyHashesFile), w.publicKeyHashes, stringValue); err != nil {
+		return nil, errors.Wrap(err, "failed to load wallet")
+	}
+
+	config, err := ioutil.ReadFile(filepath.Join(dir, configFile))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrap(err, "failed to load wallet")
+	}
+	if err == nil {
+		var c struct {
+			RemoteSigner string `json:"remote_signer"`
+		}
+		if err := json.Unmarshal(config, &c); err != nil {
+			return nil, errors.Wrapf(err, "failed to load wallet: invalid %s", configFile)
+		}
+		w.remoteSigner = c.RemoteSigner
+	}
+
+	return w, nil
+}
+
+// readWalletFile reads the entries of a wallet file into entries, which is left empty if the file doesn't exist
+func readWalletFile(path string, entries map[string]string, value func(json.RawMessage) (string, error)) error {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	var list []walletEntry
+	if err := json.Unmarshal(data, &list); err != nil {
+		return errors.Wrapf(err, "invalid %s", filepath.Base(path))
+	}
+
+	for _, entry := range list {
+		v, err := value(entry.Value)
+		if err != nil {
+			return errors.Wrapf(err, "invalid %s: invalid value of '%s'", filepath.Base(path), entry.Name)
+		}
+		entries[entry.Name] = v
+	}
+
+	return nil
+}
+
+func stringValue(v json.RawMessage) (string, error) {
+	var s string
+	err := json.Unmarshal(v, &s)
+	return s, err
+}
+
+// publicKeyValue returns the public key of an entry of public_keys, which older versions of octez-client store as
+// a locator alone and newer ones as the locator along with the key
+func publicKeyValue(v json.RawMessage) (string, error) {
+	if s, err := stringValue(v); err == nil {
+		return s, nil
+	}
+
+	var pk struct {
+		Locator string `json:"locator"`
+		Key     string `json:"key"`
+	}
+	if err := json.Unmarshal(v, &pk); err != nil {
+		return "", err
+	}
+
+	if pk.Key != "" {
+		return pk.Key, nil
+	}
+
+	return pk.Locator, nil
+}
+
+// SetRemoteSigner sets the signer keys imported as remote:<pkh> are held by, overriding the remote_signer of the config.
+func (w *Wallet) SetRemoteSigner(host string) {
+	w.remoteSigner = host
+}
+
+// Aliases returns the aliases of the wallet in alphabetical order.
+func (w *Wallet) Aliases() []string {
+	seen := map[string]bool{}
+	var aliases []string
+	for _, entries := range []map[string]string{w.secretKeys, w.publicKeys, w.publicKeyHashes} {
+		for alias := range entries {
+			if !seen[alias] {
+				seen[alias] = true
+				aliases = append(aliases, alias)
+			}
+		}
+	}
+	sort.Strings(aliases)
+
+	return aliases
+}
+
+// Address returns the public key hash (tz1, tz2 or tz3) of alias.
+func (w *Wallet) Address(alias string) (string, error) {
+	if pkh, ok := w.publicKeyHashes[alias]; ok {
+		return pkh, nil
+	}
+
+	pubKey, err := w.PubKey(alias)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get address of '%s'", alias)
+	}
+
+	return pubKey.GetAddress(), nil
+}
+
+// PubKey returns the public key of alias.
+func (w *Wallet) PubKey(alias string) (PubKey, error) {
+	pk, ok := w.publicKeys[alias]
+	if !ok {
+		return PubKey{}, errors.Errorf("failed to get public key of '%s': unknown alias", alias)
+	}
+
+	pubKey, err := PubKeyFromBase58(strings.TrimPrefix(pk, "unencrypted:"))
+	if err != nil {
+		return PubKey{}, errors.Wrapf(err, "failed to get public key of '%s'", alias)
+	}
+
+	return pubKey, nil
+}
+
+// IsRemote reports whether the secret key of alias is held by a remote signer.
+func (w *Wallet) IsRemote(alias string) bool {
+	scheme, _ := splitLocator(w.secretKeys[alias])
+	return scheme == "remote" || scheme == "http" || scheme == "https" || scheme == "tcp" || scheme == "unix"
+}
+
+/*
+Key returns the secret key of alias, calling the wallet's PasswordFunc if it is encrypted. Keys held by a remote
+signer can't be returned, see RemoteSigner.
+
+Example:
+	key, err := wallet.Key("baker")
+	if err != nil {
+		return err
+	}
+
+	signature, err := key.SignHex(operation)
+*/
+func (w *Wallet) Key(alias string) (*Key, error) {
+	locator, ok := w.secretKeys[alias]
+	if !ok {
+		return nil, errors.Errorf("failed to get key of '%s': unknown alias", alias)
+	}
+
+	var (
+		k   *Key
+		err error
+	)
+
+	switch scheme, sk := splitLocator(locator); {
+	case scheme == "unencrypted":
+		k, err = FromBase58(sk, "")
+	case scheme == "encrypted":
+		if w.password == nil {
+			return nil, errors.Errorf("failed to get key of '%s': key is encrypted and no password function was given", alias)
+		}
+
+		var passwd string
+		if passwd, err = w.password(alias); err != nil {
+			return nil, errors.Wrapf(err, "failed to get key of '%s': failed to get password", alias)
+		}
+		k, err = FromEncryptedSecret(sk, passwd)
+	case w.IsRemote(alias):
+		return nil, errors.Errorf("failed to get key of '%s': key is held by a remote signer", alias)
+	default:
+		return nil, errors.Errorf("failed to get key of '%s': unsupported key locator '%s'", alias, scheme)
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get key of '%s'", alias)
+	}
+
+	if pkh, ok := w.publicKeyHashes[alias]; ok && pkh != k.PubKey.GetAddress() {
+		return nil, errors.Errorf("failed to get key of '%s': key is the key of '%s' and not '%s'", alias, k.PubKey.GetAddress(), pkh)
+	}
+
+	return k, nil
+}
+
+/*
+SignerEndpoint returns the host of the remote signer holding the key of alias and the public key hash it holds it
+under. Keys imported as remote:<pkh> are held by the wallet's remote signer, see SetRemoteSigner.
+
+Example:
+	host, pkh, err := wallet.SignerEndpoint("baker") // http://127.0.0.1:6732 tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc
+*/
+func (w *Wallet) SignerEndpoint(alias string) (string, string, error) {
+	locator, ok := w.secretKeys[alias]
+	if !ok {
+		return "", "", errors.Errorf("failed to get signer of '%s': unknown alias", alias)
+	}
+
+	if !w.IsRemote(alias) {
+		return "", "", errors.Errorf("failed to get signer of '%s': key is not held by a remote signer", alias)
+	}
+
+	scheme, pkh := splitLocator(locator)
+	switch scheme {
+	case "remote":
+		if w.remoteSigner == "" {
+			return "", "", errors.Errorf("failed to get signer of '%s': no remote signer is configured", alias)
+		}
+
+		return cleanseHost(w.remoteSigner), pkh, nil
+	case "http", "https":
+		u, err := url.Parse(locator)
+		if err != nil {
+			return "", "", errors.Wrapf(err, "failed to get signer of '%s'", alias)
+		}
+
+		i := strings.LastIndex(u.Path, "/")
+		pkh, u.Path = u.Path[i+1:], u.Path[:i]
+
+		return u.String(), pkh, nil
+	default:
+		return "", "", errors.Errorf("failed to get signer of '%s': unsupported signer scheme '%s'", alias, scheme)
+	}
+}
+
+/*
+RemoteSigner returns a RemoteSigner for the key of alias held by a remote signer (see SignerEndpoint).
+
+Example:
+	signer, err := wallet.RemoteSigner("baker")
+	if err != nil {
+		return err
+	}
+
+	signature, err := signer.SignHex(operation)
+*/
+func (w *Wallet) RemoteSigner(alias string) (*RemoteSigner, error) {
+	host, pkh, err := w.SignerEndpoint(alias)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := NewRemoteSigner(host, pkh)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get signer of '%s'", alias)
+	}
+
+	return signer, nil
+}
+
+// splitLocator splits a key locator such as unencrypted:edsk... into its scheme and the rest
+func splitLocator(locator string) (string, string) {
+	i := strings.Index(locator, ":")
+	if i < 0 {
+		return "", locator
+	}
+
+	return locator[:i], locator[i+1:]
+}
@@ -0,0 +1,225 @@
+package keys
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goat-systems/go-tezos/v4/internal/testutils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// writeWallet writes an octez-client base directory with the files given by name and returns its path
+func writeWallet(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "wallet")
+	testutils.CheckErr(t, false, "", err)
+
+	for name, data := range files {
+		testutils.CheckErr(t, false, "", ioutil.WriteFile(filepath.Join(dir, name), []byte(data), 0600))
+	}
+
+	return dir
+}
+
+func Test_LoadWallet(t *testing.T) {
+	alice, err := Generate(Ed25519)
+	testutils.CheckErr(t, false, "", err)
+
+	bob, err := Generate(Secp256k1)
+	testutils.CheckErr(t, false, "", err)
+	bobEsk, err := bob.GetEncryptedSecretKey("passwd")
+	testutils.CheckErr(t, false, "", err)
+
+	baker, err := Generate(NistP256)
+	testutils.CheckErr(t, false, "", err)
+	server := httptest.NewServer(signerMock(baker, nil))
+	defer server.Close()
+
+	dir := writeWallet(t, map[string]string{
+		secretKeysFile: fmt.Sprintf(`[
+			{"name":"alice","value":"unencrypted:%s"},
+			{"name":"bob","value":"encrypted:%s"},
+			{"name":"baker","value":"%s/%s"},
+			{"name":"default_baker","value":"remote:%s"},
+			{"name":"tcp_baker","value":"tcp://127.0.0.1:7732/%s"},
+			{"name":"mallory","value":"unencrypted:%s"}
+		]`, alice.GetSecretKey(), bobEsk, server.URL, baker.PubKey.GetAddress(), baker.PubKey.GetAddress(), baker.PubKey.GetAddress(), alice.GetSecretKey()),
+		publicKeysFile: fmt.Sprintf(`[
+			{"name":"alice","value":{"locator":"unencrypted:%s","key":"%s"}},
+			{"name":"bob","value":"unencrypted:%s"},
+			{"name":"baker","value":{"locator":"%s/%s","key":"%s"}}
+		]`, alice.PubKey.GetPublicKey(), alice.PubKey.GetPublicKey(), bob.PubKey.GetPublicKey(), server.URL, baker.PubKey.GetAddress(), baker.PubKey.GetPublicKey()),
+		publicKeyHashesFile: fmt.Sprintf(`[
+			{"name":"alice","value":"%s"},
+			{"name":"baker","value":"%s"},
+			{"name":"mallory","value":"%s"},
+			{"name":"watched","value":"tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc"}
+		]`, alice.PubKey.GetAddress(), baker.PubKey.GetAddress(), bob.PubKey.GetAddress()),
+		configFile: fmt.Sprintf(`{"base_dir":"/home/tezos/.tezos-client","remote_signer":"%s"}`, server.URL),
+	})
+	defer os.RemoveAll(dir)
+
+	wallet, err := LoadWallet(dir, func(alias string) (string, error) {
+		if alias != "bob" {
+			return "", errors.New("unexpected alias")
+		}
+		return "passwd", nil
+	})
+	testutils.CheckErr(t, false, "", err)
+
+	assert.Equal(t, []string{"alice", "baker", "bob", "default_baker", "mallory", "tcp_baker", "watched"}, wallet.Aliases())
+
+	t.Run("is successful with an unencrypted key", func(t *testing.T) {
+		key, err := wallet.Key("alice")
+		testutils.CheckErr(t, false, "", err)
+		assert.Equal(t, alice.GetSecretKey(), key.GetSecretKey())
+		assert.False(t, wallet.IsRemote("alice"))
+	})
+
+	t.Run("is successful with an encrypted key", func(t *testing.T) {
+		key, err := wallet.Key("bob")
+		testutils.CheckErr(t, false, "", err)
+		assert.Equal(t, bob.GetSecretKey(), key.GetSecretKey())
+	})
+
+	t.Run("handles a key of another address", func(t *testing.T) {
+		_, err := wallet.Key("mallory")
+		testutils.CheckErr(t, true, fmt.Sprintf("failed to get key of 'mallory': key is the key of '%s' and not '%s'", alice.PubKey.GetAddress(), bob.PubKey.GetAddress()), err)
+	})
+
+	t.Run("handles a remote key", func(t *testing.T) {
+		_, err := wallet.Key("baker")
+		testutils.CheckErr(t, true, "failed to get key of 'baker': key is held by a remote signer", err)
+	})
+
+	t.Run("handles an unknown alias", func(t *testing.T) {
+		_, err := wallet.Key("carol")
+		testutils.CheckErr(t, true, "failed to get key of 'carol': unknown alias", err)
+	})
+
+	t.Run("is successful with public keys and addresses", func(t *testing.T) {
+		pubKey, err := wallet.PubKey("alice")
+		testutils.CheckErr(t, false, "", err)
+		assert.Equal(t, alice.PubKey.GetPublicKey(), pubKey.GetPublicKey())
+
+		pubKey, err = wallet.PubKey("bob")
+		testutils.CheckErr(t, false, "", err)
+		assert.Equal(t, bob.PubKey.GetPublicKey(), pubKey.GetPublicKey())
+
+		address, err := wallet.Address("bob")
+		testutils.CheckErr(t, false, "", err)
+		assert.Equal(t, bob.PubKey.GetAddress(), address)
+
+		address, err = wallet.Address("watched")
+		testutils.CheckErr(t, false, "", err)
+		assert.Equal(t, "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc", address)
+
+		_, err = wallet.Address("default_baker")
+		testutils.CheckErr(t, true, "failed to get address of 'default_baker': failed to get public key of 'default_baker': unknown alias", err)
+	})
+
+	t.Run("is successful with remote signers", func(t *testing.T) {
+		for _, alias := range []string{"baker", "default_baker"} {
+			assert.True(t, wallet.IsRemote(alias))
+
+			host, pkh, err := wallet.SignerEndpoint(alias)
+			testutils.CheckErr(t, false, "", err)
+			assert.Equal(t, server.URL, host)
+			assert.Equal(t, baker.PubKey.GetAddress(), pkh)
+
+			signer, err := wallet.RemoteSigner(alias)
+			testutils.CheckErr(t, false, "", err)
+			assert.Equal(t, baker.PubKey.GetPublicKey(), signer.PubKey.GetPublicKey())
+		}
+	})
+
+	t.Run("handles unsupported remote signers", func(t *testing.T) {
+		_, _, err := wallet.SignerEndpoint("tcp_baker")
+		testutils.CheckErr(t, true, "failed to get signer of 'tcp_baker': unsupported signer scheme 'tcp'", err)
+
+		_, _, err = wallet.SignerEndpoint("alice")
+		testutils.CheckErr(t, true, "failed to get signer of 'alice': key is not held by a remote signer", err)
+
+		wallet.SetRemoteSigner("")
+		_, _, err = wallet.SignerEndpoint("default_baker")
+		testutils.CheckErr(t, true, "failed to get signer of 'default_baker': no remote signer is configured", err)
+	})
+}
+
+// Test_LoadWallet_Octez loads the wallet files octez-client writes for the bootstrap accounts of its sandbox
+func Test_LoadWallet_Octez(t *testing.T) {
+	dir := writeWallet(t, map[string]string{
+		secretKeysFile: `[ { "name": "bootstrap2",
+    "value": "unencrypted:edsk39qAm1fiMjgmPkw1EgQYkMzkJezLNewd7PLNHTkr6w9XA2zdfo" },
+  { "name": "bootstrap1",
+    "value": "unencrypted:edsk3gUfUPyBSfrS9CCgmCiQsTCHGkviBDusMxDJstFtojtc1zcpsh" } ]`,
+		publicKeysFile: `[ { "name": "bootstrap2",
+    "value":
+      { "locator":
+          "unencrypted:edpktzNbDAUjUk697W7gYg2CRuBQjyPxbEg8dLccYYwKSKvkPvjtV9",
+        "key": "edpktzNbDAUjUk697W7gYg2CRuBQjyPxbEg8dLccYYwKSKvkPvjtV9" } },
+  { "name": "bootstrap1",
+    "value":
+      { "locator":
+          "unencrypted:edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav",
+        "key": "edpkuBknW28nW72KG6RoHtYW7p12T6GKc7nAbwYX5m8Wd9sDVC9yav" } } ]`,
+		publicKeyHashesFile: `[ { "name": "bootstrap2", "value": "tz1gjaF81ZRRvdzjobyfVNsAeSC6PScjfQwN" },
+  { "name": "bootstrap1", "value": "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx" } ]`,
+	})
+	defer os.RemoveAll(dir)
+
+	wallet, err := LoadWallet(dir, nil)
+	testutils.CheckErr(t, false, "", err)
+
+	for alias, address := range map[string]string{
+		"bootstrap1": "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx",
+		"bootstrap2": "tz1gjaF81ZRRvdzjobyfVNsAeSC6PScjfQwN",
+	} {
+		key, err := wallet.Key(alias)
+		testutils.CheckErr(t, false, "", err)
+		assert.Equal(t, address, key.PubKey.GetAddress())
+
+		pubKey, err := wallet.PubKey(alias)
+		testutils.CheckErr(t, false, "", err)
+		assert.Equal(t, pubKey.GetPublicKey(), key.PubKey.GetPublicKey())
+	}
+}
+
+func Test_LoadWallet_Errors(t *testing.T) {
+	cases := []struct {
+		name        string
+		files       map[string]string
+		alias       string
+		password    PasswordFunc
+		wantErr     bool
+		containsErr string
+	}{
+		{"handles an invalid file", map[string]string{secretKeysFile: `{}`}, "", nil, true, "failed to load wallet: invalid secret_keys"},
+		{"handles an invalid value", map[string]string{publicKeyHashesFile: `[{"name":"alice","value":1}]`}, "", nil, true, "failed to load wallet: invalid public_key_hashs: invalid value of 'alice'"},
+		{"handles an invalid config", map[string]string{configFile: `[]`}, "", nil, true, "failed to load wallet: invalid config"},
+		{"handles a missing password function", map[string]string{secretKeysFile: `[{"name":"alice","value":"encrypted:edesk1fddn27MaLcQVEdZpAYiyGQNm6UjtWiBfNP2ZenTy3CFsoSVJgeHM9pP9cvLJ2r5Xp2quQ5mYexW1LRKee2"}]`}, "alice", nil, true, "failed to get key of 'alice': key is encrypted and no password function was given"},
+		{"handles a failing password function", map[string]string{secretKeysFile: `[{"name":"alice","value":"encrypted:edesk1fddn27MaLcQVEdZpAYiyGQNm6UjtWiBfNP2ZenTy3CFsoSVJgeHM9pP9cvLJ2r5Xp2quQ5mYexW1LRKee2"}]`}, "alice", func(string) (string, error) { return "", errors.New("cancelled") }, true, "failed to get key of 'alice': failed to get password: cancelled"},
+		{"handles an invalid password", map[string]string{secretKeysFile: `[{"name":"alice","value":"encrypted:edesk1fddn27MaLcQVEdZpAYiyGQNm6UjtWiBfNP2ZenTy3CFsoSVJgeHM9pP9cvLJ2r5Xp2quQ5mYexW1LRKee2"}]`}, "alice", func(string) (string, error) { return "wrong", nil }, true, "failed to get key of 'alice': failed to import key: invalid password"},
+		{"handles an unsupported locator", map[string]string{secretKeysFile: `[{"name":"alice","value":"ledger://tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc"}]`}, "alice", nil, true, "failed to get key of 'alice': unsupported key locator 'ledger'"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeWallet(t, tt.files)
+			defer os.RemoveAll(dir)
+
+			wallet, err := LoadWallet(dir, tt.password)
+			if err == nil {
+				_, err = wallet.Key(tt.alias)
+			}
+			testutils.CheckErr(t, tt.wantErr, tt.containsErr, err)
+		})
+	}
+
+	_, err := LoadWallet(filepath.Join(os.TempDir(), "missing-wallet"), nil)
+	testutils.CheckErr(t, true, "failed to load wallet", err)
+}
@@ -0,0 +1,138 @@
+package keys
+
+import (
+	"github.com/goat-systems/go-tezos/v4/tezos"
+	"github.com/pkg/errors"
+)
+
+// WatermarkKind is the first byte of a watermark, which tells what kind of data is signed.
+type WatermarkKind byte
+
+// The watermark kinds of the data a key signs. Blocks and consensus operations are watermarked with the chain they
+// are signed for, generic operations are not.
+const (
+	// WatermarkBlock is the watermark of a block header before Tenderbake (Emmy*).
+	WatermarkBlock WatermarkKind = 0x01
+	// WatermarkEndorsement is the watermark of an endorsement before Tenderbake (Emmy*).
+	WatermarkEndorsement WatermarkKind = 0x02
+	// WatermarkGenericOperation is the watermark of operations such as transactions, originations and delegations.
+	WatermarkGenericOperation WatermarkKind = 0x03
+	// WatermarkTenderbakeBlock is the watermark of a block header since Tenderbake.
+	WatermarkTenderbakeBlock WatermarkKind = 0x11
+	// WatermarkPreendorsement is the watermark of a preendorsement since Tenderbake.
+	WatermarkPreendorsement WatermarkKind = 0x12
+	// WatermarkTenderbakeEndorsement is the watermark of an endorsement since Tenderbake.
+	WatermarkTenderbakeEndorsement WatermarkKind = 0x13
+)
+
+// chainIDLength is the length of the chain id following the kind of a block or consensus operation watermark
+const chainIDLength = 4
+
+/*
+Watermark precedes the bytes a signer signs and tells what they are, so that a signature of one kind of data can't be
+passed off as the signature of another: a watermark kind followed by the chain id for blocks and consensus
+operations. See NewWatermark.
+*/
+type Watermark []byte
+
+// GenericOperationWatermark is the watermark of operations such as transactions, originations and delegations.
+var GenericOperationWatermark = Watermark{byte(WatermarkGenericOperation)}
+
+/*
+NewWatermark returns the watermark of a kind of data signed for a chain.
+
+Parameters:
+
+	kind:
+		The kind of data signed (e.g. WatermarkTenderbakeBlock).
+
+	chainID:
+		The chain id (e.g. NetXdQprcVkpaWU) blocks and consensus operations are signed for. It must be empty for
+		generic operations, which are not tied to a chain by their watermark.
+
+Example:
+	watermark, err := keys.NewWatermark(keys.WatermarkTenderbakeEndorsement, "NetXdQprcVkpaWU")
+	if err != nil {
+		return err
+	}
+
+	signature, err := key.Sign(forged, watermark)
+*/
+func NewWatermark(kind WatermarkKind, chainID string) (Watermark, error) {
+	switch kind {
+	case WatermarkGenericOperation:
+		if chainID != "" {
+			return nil, errors.New("invalid watermark: generic operations are not watermarked with a chain id")
+		}
+
+		return GenericOperationWatermark, nil
+	case WatermarkBlock, WatermarkEndorsement, WatermarkTenderbakeBlock, WatermarkPreendorsement, WatermarkTenderbakeEndorsement:
+		id, err := tezos.ParseChainID(chainID)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid watermark")
+		}
+
+		return append(Watermark{byte(kind)}, id[:]...), nil
+	default:
+		return nil, errors.Errorf("invalid watermark: unknown kind 0x%02x", byte(kind))
+	}
+}
+
+/*
+ParseWatermark splits watermarked bytes, such as the bytes a remote signer is asked to sign, into their watermark and
+the data that follows it.
+
+Example:
+	watermark, forged, err := keys.ParseWatermark(msg)
+	if err != nil {
+		return err
+	}
+
+	if watermark.Kind() != keys.WatermarkGenericOperation {
+		return errors.New("refusing to sign consensus operations")
+	}
+*/
+func ParseWatermark(msg []byte) (Watermark, []byte, error) {
+	if len(msg) == 0 {
+		return nil, nil, errors.New("invalid watermark: empty message")
+	}
+
+	switch kind := WatermarkKind(msg[0]); kind {
+	case WatermarkGenericOperation:
+		return GenericOperationWatermark, msg[1:], nil
+	case WatermarkBlock, WatermarkEndorsement, WatermarkTenderbakeBlock, WatermarkPreendorsement, WatermarkTenderbakeEndorsement:
+		if len(msg) < 1+chainIDLength {
+			return nil, nil, errors.Errorf("invalid watermark: kind 0x%02x is missing its chain id", byte(kind))
+		}
+
+		return Watermark(msg[:1+chainIDLength]), msg[1+chainIDLength:], nil
+	default:
+		return nil, nil, errors.Errorf("invalid watermark: unknown kind 0x%02x", byte(kind))
+	}
+}
+
+// Kind returns the kind of the watermark.
+func (w Watermark) Kind() WatermarkKind {
+	if len(w) == 0 {
+		return 0
+	}
+
+	return WatermarkKind(w[0])
+}
+
+// ChainID returns the base58 encoded chain id of the watermark, empty for generic operations.
+func (w Watermark) ChainID() string {
+	if len(w) != 1+chainIDLength {
+		return ""
+	}
+
+	var id tezos.ChainID
+	copy(id[:], w[1:])
+
+	return id.String()
+}
+
+// watermark returns msg preceded by w
+func (w Watermark) watermark(msg []byte) []byte {
+	return append(append(make([]byte, 0, len(w)+len(msg)), w...), msg...)
+}
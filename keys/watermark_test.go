@@ -0,0 +1,137 @@
+package keys
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/goat-systems/go-tezos/v4/internal/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewWatermark(t *testing.T) {
+	type input struct {
+		kind    WatermarkKind
+		chainID string
+	}
+
+	type want struct {
+		err         bool
+		errContains string
+		watermark   string
+	}
+
+	cases := []struct {
+		name  string
+		input input
+		want  want
+	}{
+		{"is successful with a generic operation", input{WatermarkGenericOperation, ""}, want{false, "", "03"}},
+		{"is successful with a block", input{WatermarkBlock, "NetXdQprcVkpaWU"}, want{false, "", "017a06a770"}},
+		{"is successful with an endorsement", input{WatermarkEndorsement, "NetXdQprcVkpaWU"}, want{false, "", "027a06a770"}},
+		{"is successful with a tenderbake block", input{WatermarkTenderbakeBlock, "NetXdQprcVkpaWU"}, want{false, "", "117a06a770"}},
+		{"is successful with a preendorsement", input{WatermarkPreendorsement, "NetXdQprcVkpaWU"}, want{false, "", "127a06a770"}},
+		{"is successful with a tenderbake endorsement", input{WatermarkTenderbakeEndorsement, "NetXdQprcVkpaWU"}, want{false, "", "137a06a770"}},
+		{"handles a generic operation with a chain id", input{WatermarkGenericOperation, "NetXdQprcVkpaWU"}, want{true, "generic operations are not watermarked with a chain id", ""}},
+		{"handles a block without a chain id", input{WatermarkBlock, ""}, want{true, "invalid watermark: invalid chain id", ""}},
+		{"handles an unknown kind", input{WatermarkKind(0x04), ""}, want{true, "invalid watermark: unknown kind 0x04", ""}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			watermark, err := NewWatermark(tt.input.kind, tt.input.chainID)
+			testutils.CheckErr(t, tt.want.err, tt.want.errContains, err)
+			if tt.want.err {
+				return
+			}
+
+			assert.Equal(t, tt.want.watermark, hex.EncodeToString(watermark))
+			assert.Equal(t, tt.input.kind, watermark.Kind())
+			assert.Equal(t, tt.input.chainID, watermark.ChainID())
+		})
+	}
+}
+
+func Test_ParseWatermark(t *testing.T) {
+	type want struct {
+		err         bool
+		errContains string
+		kind        WatermarkKind
+		chainID     string
+		data        string
+	}
+
+	cases := []struct {
+		name  string
+		input string
+		want  want
+	}{
+		{"is successful with a generic operation", "03a732d3520e", want{false, "", WatermarkGenericOperation, "", "a732d3520e"}},
+		{"is successful with a tenderbake endorsement", "137a06a770a732d3520e", want{false, "", WatermarkTenderbakeEndorsement, "NetXdQprcVkpaWU", "a732d3520e"}},
+		{"handles a missing chain id", "117a06", want{true, "invalid watermark: kind 0x11 is missing its chain id", 0, "", ""}},
+		{"handles an unknown kind", "a732d3520e", want{true, "invalid watermark: unknown kind 0xa7", 0, "", ""}},
+		{"handles an empty message", "", want{true, "invalid watermark: empty message", 0, "", ""}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := hex.DecodeString(tt.input)
+			testutils.CheckErr(t, false, "", err)
+
+			watermark, data, err := ParseWatermark(msg)
+			testutils.CheckErr(t, tt.want.err, tt.want.errContains, err)
+			if tt.want.err {
+				return
+			}
+
+			assert.Equal(t, tt.want.kind, watermark.Kind())
+			assert.Equal(t, tt.want.chainID, watermark.ChainID())
+			assert.Equal(t, tt.want.data, hex.EncodeToString(data))
+		})
+	}
+}
+
+func Test_Sign_Watermark(t *testing.T) {
+	// A forged operation whose branch legitimately starts with 0x03.
+	msg, err := hex.DecodeString("03a732d3520eeaa3de98d78e5e5cb6c85f72204fd46feb9f76853841d4a701ad6c0008ba0cb2fad622697145cf1665124096d25bc31e00")
+	testutils.CheckErr(t, false, "", err)
+
+	key, err := Generate(Ed25519)
+	testutils.CheckErr(t, false, "", err)
+
+	// SignBytes takes the leading 0x03 for the watermark, Sign always prepends it.
+	signature, err := key.SignBytes(msg)
+	testutils.CheckErr(t, false, "", err)
+
+	unwatermarked, err := key.Sign(msg, nil)
+	testutils.CheckErr(t, false, "", err)
+	assert.Equal(t, unwatermarked, signature)
+
+	generic, err := key.Sign(msg, GenericOperationWatermark)
+	testutils.CheckErr(t, false, "", err)
+	assert.NotEqual(t, generic, signature)
+
+	ok, err := key.PubKey.VerifyWithWatermark(msg, GenericOperationWatermark, generic)
+	testutils.CheckErr(t, false, "", err)
+	assert.True(t, ok)
+
+	endorsement, err := NewWatermark(WatermarkTenderbakeEndorsement, "NetXdQprcVkpaWU")
+	testutils.CheckErr(t, false, "", err)
+
+	signature, err = key.Sign(msg, endorsement)
+	testutils.CheckErr(t, false, "", err)
+
+	ok, err = key.PubKey.VerifyWithWatermark(msg, endorsement, signature)
+	testutils.CheckErr(t, false, "", err)
+	assert.True(t, ok)
+
+	ok, err = key.PubKey.Verify(msg, signature)
+	testutils.CheckErr(t, false, "", err)
+	assert.False(t, ok)
+
+	preendorsement, err := NewWatermark(WatermarkPreendorsement, "NetXdQprcVkpaWU")
+	testutils.CheckErr(t, false, "", err)
+
+	ok, err = key.PubKey.VerifyWithWatermark(msg, preendorsement, signature)
+	testutils.CheckErr(t, false, "", err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,393 @@
+package michelson
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	compareOps      = `(EQ|NEQ|LT|GT|LE|GE)`
+	regCMP          = regexp.MustCompile(`^CMP` + compareOps + `$`)
+	regIF           = regexp.MustCompile(`^IF` + compareOps + `$`)
+	regIFCMP        = regexp.MustCompile(`^IFCMP` + compareOps + `$`)
+	regASSERT       = regexp.MustCompile(`^ASSERT_` + compareOps + `$`)
+	regASSERTCMP    = regexp.MustCompile(`^ASSERT_CMP` + compareOps + `$`)
+	regDIP          = regexp.MustCompile(`^DI(I+)P$`)
+	regDUP          = regexp.MustCompile(`^DU(U+)P$`)
+	regCADR         = regexp.MustCompile(`^C([AD]{2,})R$`)
+	regSETCADR      = regexp.MustCompile(`^SET_C([AD]+)R$`)
+	regMAPCADR      = regexp.MustCompile(`^MAP_C([AD]+)R$`)
+	regPAIR         = regexp.MustCompile(`^P[API]+R$`)
+	regUNPAIR       = regexp.MustCompile(`^UNP[API]+R$`)
+	regASSERTBranch = regexp.MustCompile(`^ASSERT_(NONE|SOME|LEFT|RIGHT)$`)
+)
+
+// expandMacro returns the instructions a macro stands for, or n itself if it is a primitive (see Parse)
+func expandMacro(n node) (node, error) {
+	if n.IsSeq || n.Prim == "" || primitives[n.Prim] {
+		return n, nil
+	}
+
+	fail := seq(prim("UNIT"), prim("FAILWITH"))
+
+	switch {
+	case n.Prim == "FAIL":
+		return withArgs(n, 0, func() node {
+			return fail
+		})
+	case n.Prim == "ASSERT":
+		return withArgs(n, 0, func() node {
+			return seq(prim("IF", seq(), seq(fail)))
+		})
+	case regASSERT.MatchString(n.Prim):
+		op := regASSERT.FindStringSubmatch(n.Prim)[1]
+		return withArgs(n, 0, func() node {
+			return seq(prim(op), prim("IF", seq(), seq(fail)))
+		})
+	case regASSERTCMP.MatchString(n.Prim):
+		op := regASSERTCMP.FindStringSubmatch(n.Prim)[1]
+		return withArgs(n, 0, func() node {
+			return seq(seq(prim("COMPARE"), prim(op)), prim("IF", seq(), seq(fail)))
+		})
+	case regASSERTBranch.MatchString(n.Prim):
+		return withArgs(n, 0, func() node {
+			switch n.Prim {
+			case "ASSERT_NONE":
+				return seq(prim("IF_NONE", seq(), seq(fail)))
+			case "ASSERT_SOME":
+				return seq(prim("IF_NONE", seq(fail), seq()))
+			case "ASSERT_LEFT":
+				return seq(prim("IF_LEFT", seq(), seq(fail)))
+			default:
+				return seq(prim("IF_LEFT", seq(fail), seq()))
+			}
+		})
+	case regCMP.MatchString(n.Prim):
+		op := regCMP.FindStringSubmatch(n.Prim)[1]
+		return withArgs(n, 0, func() node {
+			return seq(prim("COMPARE"), node{Prim: op, Annots: n.Annots})
+		})
+	case regIF.MatchString(n.Prim):
+		op := regIF.FindStringSubmatch(n.Prim)[1]
+		return withArgs(n, 2, func() node {
+			return seq(prim(op), prim("IF", n.Args...))
+		})
+	case regIFCMP.MatchString(n.Prim):
+		op := regIFCMP.FindStringSubmatch(n.Prim)[1]
+		return withArgs(n, 2, func() node {
+			return seq(seq(prim("COMPARE"), prim(op)), prim("IF", n.Args...))
+		})
+	case n.Prim == "IF_SOME":
+		return withArgs(n, 2, func() node {
+			return seq(node{Prim: "IF_NONE", Args: []node{n.Args[1], n.Args[0]}, Annots: n.Annots})
+		})
+	case n.Prim == "IF_RIGHT":
+		return withArgs(n, 2, func() node {
+			return seq(node{Prim: "IF_LEFT", Args: []node{n.Args[1], n.Args[0]}, Annots: n.Annots})
+		})
+	case regDIP.MatchString(n.Prim):
+		depth := strconv.Itoa(len(regDIP.FindStringSubmatch(n.Prim)[1]) + 1)
+		return withArgs(n, 1, func() node {
+			return seq(prim("DIP", node{Int: &depth}, n.Args[0]))
+		})
+	case regDUP.MatchString(n.Prim):
+		depth := strconv.Itoa(len(regDUP.FindStringSubmatch(n.Prim)[1]) + 1)
+		return withArgs(n, 0, func() node {
+			return seq(node{Prim: "DUP", Args: []node{{Int: &depth}}, Annots: n.Annots})
+		})
+	case regCADR.MatchString(n.Prim):
+		path := regCADR.FindStringSubmatch(n.Prim)[1]
+		return withArgs(n, 0, func() node {
+			var instructions []node
+			for _, c := range path {
+				if c == 'A' {
+					instructions = append(instructions, prim("CAR"))
+				} else {
+					instructions = append(instructions, prim("CDR"))
+				}
+			}
+
+			// annotations go on the last access
+			instructions[len(instructions)-1].Annots = n.Annots
+			return seq(instructions...)
+		})
+	case regSETCADR.MatchString(n.Prim):
+		path := regSETCADR.FindStringSubmatch(n.Prim)[1]
+		field, annots, err := fieldAnnot(n)
+		if err != nil {
+			return node{}, err
+		}
+
+		return withArgs(n, 0, func() node {
+			var instructions []node
+			if path[len(path)-1] == 'A' {
+				if field != "" {
+					instructions = append(instructions, prim("DUP"), annotated(prim("CAR"), field), prim("DROP"))
+				}
+				instructions = append(instructions, annotated(prim("CDR"), "@%%"), prim("SWAP"), annotated(prim("PAIR"), orEmptyField(field), "%@"))
+			} else {
+				if field != "" {
+					instructions = append(instructions, prim("DUP"), annotated(prim("CDR"), field), prim("DROP"))
+				}
+				instructions = append(instructions, annotated(prim("CAR"), "@%%"), annotated(prim("PAIR"), "%@", orEmptyField(field)))
+			}
+
+			return updateCADR(path, seq(instructions...), annots)
+		})
+	case regMAPCADR.MatchString(n.Prim):
+		path := regMAPCADR.FindStringSubmatch(n.Prim)[1]
+		field, annots, err := fieldAnnot(n)
+		if err != nil {
+			return node{}, err
+		}
+
+		if len(n.Args) == 1 && !n.Args[0].IsSeq {
+			return node{}, errors.Errorf("macro %s expects a sequence", n.Prim)
+		}
+
+		return withArgs(n, 1, func() node {
+			// the updated value is named after the field
+			access := []string{}
+			if field != "" {
+				access = append(access, "@"+field[1:])
+			}
+
+			var init node
+			if path[len(path)-1] == 'A' {
+				init = seq(
+					prim("DUP"),
+					annotated(prim("CDR"), "@%%"),
+					prim("DIP", seq(annotated(prim("CAR"), access...), n.Args[0])),
+					prim("SWAP"),
+					annotated(prim("PAIR"), orEmptyField(field), "%@"),
+				)
+			} else {
+				init = seq(
+					prim("DUP"),
+					annotated(prim("CDR"), access...),
+					n.Args[0],
+					prim("SWAP"),
+					annotated(prim("CAR"), "@%%"),
+					annotated(prim("PAIR"), "%@", orEmptyField(field)),
+				)
+			}
+
+			return updateCADR(path, init, annots)
+		})
+	case regPAIR.MatchString(n.Prim):
+		if tree, ok := parsePairMacro(n.Prim[:len(n.Prim)-1]); ok {
+			var fields, annots []string
+			for _, annot := range n.Annots {
+				if strings.HasPrefix(annot, "%") {
+					fields = append(fields, annot)
+				} else {
+					annots = append(annots, annot)
+				}
+			}
+
+			if len(fields) > tree.leaves() {
+				return node{}, errors.Errorf("macro %s expects at most %d field annotations but got %d", n.Prim, tree.leaves(), len(fields))
+			}
+
+			return withArgs(n, 0, func() node {
+				expanded := tree.pair(&fields)
+				pair := &expanded.Seq[len(expanded.Seq)-1]
+				pair.Annots = append(pair.Annots, annots...)
+				return expanded
+			})
+		}
+	case regUNPAIR.MatchString(n.Prim):
+		if tree, ok := parsePairMacro(n.Prim[2 : len(n.Prim)-1]); ok {
+			if len(n.Annots) > 0 {
+				return node{}, errors.Errorf("macro %s does not take annotations", n.Prim)
+			}
+
+			return withArgs(n, 0, func() node {
+				return tree.unpair()
+			})
+		}
+	}
+
+	return node{}, errors.Errorf("unknown primitive '%s'", n.Prim)
+}
+
+// withArgs expands a macro with expand if it has exactly args arguments
+func withArgs(n node, args int, expand func() node) (node, error) {
+	if len(n.Args) != args {
+		return node{}, errors.Errorf("macro %s expects %d arguments but got %d", n.Prim, args, len(n.Args))
+	}
+
+	return expand(), nil
+}
+
+// fieldAnnot returns the field annotation of n, if any, and its other annotations
+func fieldAnnot(n node) (string, []string, error) {
+	var field string
+	var annots []string
+	for _, annot := range n.Annots {
+		if !strings.HasPrefix(annot, "%") {
+			annots = append(annots, annot)
+		} else if field != "" {
+			return "", nil, errors.Errorf("macro %s expects at most 1 field annotation", n.Prim)
+		} else {
+			field = annot
+		}
+	}
+
+	return field, annots, nil
+}
+
+func annotated(n node, annots ...string) node {
+	if len(annots) > 0 {
+		n.Annots = annots
+	}
+
+	return n
+}
+
+// orEmptyField returns field, or the empty field annotation that leaves a field unnamed
+func orEmptyField(field string) string {
+	if field == "" {
+		return "%"
+	}
+
+	return field
+}
+
+// updateCADR wraps the update of the last access of path in the accesses before it, keeping the other fields of
+// every pair it rebuilds (SET_C[AD]+R and MAP_C[AD]+R)
+func updateCADR(path string, update node, annots []string) node {
+	for i := len(path) - 2; i >= 0; i-- {
+		var pairAnnots []string
+		if i == 0 {
+			pairAnnots = annots
+		}
+
+		if path[i] == 'A' {
+			update = seq(
+				prim("DUP"),
+				prim("DIP", seq(annotated(prim("CAR"), "@%%"), update)),
+				annotated(prim("CDR"), "@%%"),
+				prim("SWAP"),
+				annotated(prim("PAIR"), append([]string{"%@", "%@"}, pairAnnots...)...),
+			)
+		} else {
+			update = seq(
+				prim("DUP"),
+				prim("DIP", seq(annotated(prim("CDR"), "@%%"), update)),
+				annotated(prim("CAR"), "@%%"),
+				annotated(prim("PAIR"), append([]string{"%@", "%@"}, pairAnnots...)...),
+			)
+		}
+	}
+
+	return update
+}
+
+// pairTree is the tree of pairs P[AP]+IR and UNP[AP]+IR macros build and destructure, a nil pairTree is a leaf
+type pairTree struct {
+	left, right *pairTree
+}
+
+// parsePairMacro parses the P(A|P..)(I|P..) part of a pair macro
+func parsePairMacro(s string) (*pairTree, bool) {
+	var parse func(s string) (*pairTree, string, bool)
+	parse = func(s string) (*pairTree, string, bool) {
+		if !strings.HasPrefix(s, "P") {
+			return nil, s, false
+		}
+		s = s[1:]
+
+		t := &pairTree{}
+		ok := true
+		if strings.HasPrefix(s, "A") {
+			s = s[1:]
+		} else if t.left, s, ok = parse(s); !ok {
+			return nil, s, false
+		}
+
+		if strings.HasPrefix(s, "I") {
+			s = s[1:]
+		} else if t.right, s, ok = parse(s); !ok {
+			return nil, s, false
+		}
+
+		return t, s, true
+	}
+
+	t, rest, ok := parse(s)
+	return t, ok && rest == ""
+}
+
+func (t *pairTree) leaves() int {
+	if t == nil {
+		return 1
+	}
+
+	return t.left.leaves() + t.right.leaves()
+}
+
+// pair expands t as the Michelson reference defines it: PA(\right)R is DIP ((\right)R) ; PAIR, P(\left)IR is (\left)R ; PAIR and
+// P(\left)(\right)R is (\left)R ; DIP ((\right)R) ; PAIR. Field annotations are taken from fields for the leaves
+// from left to right.
+func (t *pairTree) pair(fields *[]string) node {
+	var instructions []node
+	var leftField, rightField string
+	if t.left == nil {
+		leftField = nextField(fields)
+	} else {
+		instructions = append(instructions, inline(t.left.pair(fields)))
+	}
+
+	if t.right == nil {
+		rightField = nextField(fields)
+	} else {
+		instructions = append(instructions, prim("DIP", t.right.pair(fields)))
+	}
+
+	var annots []string
+	if rightField != "" {
+		annots = []string{orEmptyField(leftField), rightField}
+	} else if leftField != "" {
+		annots = []string{leftField}
+	}
+
+	return seq(append(instructions, annotated(prim("PAIR"), annots...))...)
+}
+
+func nextField(fields *[]string) string {
+	if len(*fields) == 0 {
+		return ""
+	}
+
+	field := (*fields)[0]
+	*fields = (*fields)[1:]
+	return field
+}
+
+// unpair expands t as the Michelson reference defines it: UNPA(\right)R is UNPAIR ; DIP (UN(\right)R), UNP(\left)IR is
+// UNPAIR ; UN(\left)R and UNP(\left)(\right)R is UNPAIR ; DIP (UN(\right)R) ; UN(\left)R.
+func (t *pairTree) unpair() node {
+	instructions := []node{prim("UNPAIR")}
+	if t.right != nil {
+		instructions = append(instructions, prim("DIP", t.right.unpair()))
+	}
+
+	if t.left != nil {
+		instructions = append(instructions, inline(t.left.unpair()))
+	}
+
+	return seq(instructions...)
+}
+
+// inline returns the instruction of a sequence of one, like the PAIR of P(A)(I)R, or the sequence otherwise
+func inline(n node) node {
+	if len(n.Seq) == 1 {
+		return n.Seq[0]
+	}
+
+	return n
+}
@@ -0,0 +1,53 @@
+package michelson
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// node is a Micheline expression: a primitive application, an int, a string, bytes or a sequence
+type node struct {
+	Prim   string   `json:"prim,omitempty"`
+	Args   []node   `json:"args,omitempty"`
+	Annots []string `json:"annots,omitempty"`
+	Int    *string  `json:"int,omitempty"`
+	String *string  `json:"string,omitempty"`
+	Bytes  *string  `json:"bytes,omitempty"`
+	Seq    []node   `json:"-"`
+	IsSeq  bool     `json:"-"`
+}
+
+type object node
+
+func prim(name string, args ...node) node {
+	return node{Prim: name, Args: args}
+}
+
+func seq(items ...node) node {
+	if items == nil {
+		items = []node{}
+	}
+
+	return node{Seq: items, IsSeq: true}
+}
+
+func (n *node) UnmarshalJSON(v []byte) error {
+	if v = bytes.TrimSpace(v); len(v) > 0 && v[0] == '[' {
+		n.IsSeq = true
+		n.Seq = []node{}
+		return json.Unmarshal(v, &n.Seq)
+	}
+
+	return json.Unmarshal(v, (*object)(n))
+}
+
+func (n node) MarshalJSON() ([]byte, error) {
+	if n.IsSeq {
+		if n.Seq == nil {
+			return []byte("[]"), nil
+		}
+		return json.Marshal(n.Seq)
+	}
+
+	return json.Marshal(object(n))
+}
@@ -0,0 +1,359 @@
+package michelson
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+/*
+Parse parses Michelson source, such as the contents of a .tz file, into Micheline JSON that the forge package and
+rpc.Script accept. The following macros are expanded the way the node expands them:
+	FAIL, ASSERT, ASSERT_{EQ|NEQ|LT|GT|LE|GE}, ASSERT_CMP{EQ|NEQ|LT|GT|LE|GE}, ASSERT_{NONE|SOME|LEFT|RIGHT},
+	CMP{EQ|NEQ|LT|GT|LE|GE}, IF{EQ|NEQ|LT|GT|LE|GE}, IFCMP{EQ|NEQ|LT|GT|LE|GE}, IF_SOME, IF_RIGHT,
+	DI{I+}P, DU{U+}P, C{A|D}{A|D}+R, SET_C{A|D}+R, MAP_C{A|D}+R, P{A|P}+IR and UNP{A|P}+IR
+
+Any other primitive that is not a primitive of the Micheline encoding, such as a misspelled instruction, is an error.
+
+A source of several expressions separated by semicolons, like a contract's parameter, storage and code sections,
+parses to a sequence. A single expression, like storage data, parses to that expression.
+
+Example:
+	code, err := michelson.Parse(`parameter unit; storage unit; code { CDR ; NIL operation ; PAIR }`)
+	if err != nil {
+		return err
+	}
+
+	script := rpc.Script{Code: code, Storage: storage}
+*/
+func Parse(src string) (*json.RawMessage, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse michelson")
+	}
+
+	p := &parser{tokens: tokens}
+	items, trailing, err := p.sequence(tokenEOF)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse michelson")
+	}
+
+	var n node
+	if len(items) == 1 && !trailing {
+		n = items[0]
+	} else {
+		n = seq(items...)
+	}
+
+	v, err := json.Marshal(n)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse michelson")
+	}
+
+	raw := json.RawMessage(v)
+	return &raw, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+
+	return t
+}
+
+// sequence parses expressions separated by semicolons until end, and reports whether the last one was followed by a semicolon
+func (p *parser) sequence(end tokenKind) ([]node, bool, error) {
+	items := []node{}
+	trailing := false
+	for p.peek().kind != end {
+		item, err := p.expression()
+		if err != nil {
+			return nil, false, err
+		}
+		items = append(items, item)
+
+		trailing = p.peek().kind == tokenSemicolon
+		if !trailing {
+			break
+		}
+		p.next()
+	}
+
+	if t := p.next(); t.kind != end {
+		return nil, false, t.errorf("expected %s but got %s", end, t)
+	}
+
+	return items, trailing, nil
+}
+
+// expression parses a primitive application with its annotations and arguments, or a single argument
+func (p *parser) expression() (node, error) {
+	t := p.peek()
+	if t.kind != tokenIdent {
+		return p.argument()
+	}
+	p.next()
+
+	n := node{Prim: t.value}
+	for p.peek().kind == tokenAnnot {
+		n.Annots = append(n.Annots, p.next().value)
+	}
+
+	for {
+		switch p.peek().kind {
+		case tokenInt, tokenString, tokenBytes, tokenIdent, tokenOpenParen, tokenOpenBrace:
+			arg, err := p.argument()
+			if err != nil {
+				return node{}, err
+			}
+			n.Args = append(n.Args, arg)
+		default:
+			expanded, err := expandMacro(n)
+			if err != nil {
+				return node{}, t.errorf("%s", err.Error())
+			}
+			return expanded, nil
+		}
+	}
+}
+
+// argument parses an expression that needs no parentheses
+func (p *parser) argument() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenInt:
+		v := t.value
+		return node{Int: &v}, nil
+	case tokenString:
+		v := t.value
+		return node{String: &v}, nil
+	case tokenBytes:
+		v := t.value
+		return node{Bytes: &v}, nil
+	case tokenIdent:
+		n, err := expandMacro(node{Prim: t.value})
+		if err != nil {
+			return node{}, t.errorf("%s", err.Error())
+		}
+		return n, nil
+	case tokenOpenParen:
+		n, err := p.expression()
+		if err != nil {
+			return node{}, err
+		}
+
+		if c := p.next(); c.kind != tokenCloseParen {
+			return node{}, c.errorf("expected %s but got %s", tokenCloseParen, c)
+		}
+		return n, nil
+	case tokenOpenBrace:
+		items, _, err := p.sequence(tokenCloseBrace)
+		if err != nil {
+			return node{}, err
+		}
+		return seq(items...), nil
+	default:
+		return node{}, t.errorf("unexpected %s", t)
+	}
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenAnnot
+	tokenInt
+	tokenString
+	tokenBytes
+	tokenOpenParen
+	tokenCloseParen
+	tokenOpenBrace
+	tokenCloseBrace
+	tokenSemicolon
+)
+
+func (k tokenKind) String() string {
+	return [...]string{"end of input", "primitive", "annotation", "int", "string", "bytes", "'('", "')'", "'{'", "'}'", "';'"}[k]
+}
+
+type token struct {
+	kind  tokenKind
+	value string
+	line  int
+	col   int
+}
+
+func (t token) String() string {
+	if t.value == "" {
+		return t.kind.String()
+	}
+
+	return fmt.Sprintf("%s '%s'", t.kind, t.value)
+}
+
+func (t token) errorf(format string, args ...interface{}) error {
+	return errors.Errorf("line %d, column %d: %s", t.line, t.col, fmt.Sprintf(format, args...))
+}
+
+func lex(src string) ([]token, error) {
+	var tokens []token
+	line, col := 1, 1
+
+	advance := func(n int) {
+		for _, c := range src[:n] {
+			if c == '\n' {
+				line++
+				col = 1
+			} else {
+				col++
+			}
+		}
+		src = src[n:]
+	}
+
+	// skip moves past whitespace and comments
+	skip := func() error {
+		for len(src) > 0 {
+			switch {
+			case strings.ContainsRune(" \t\r\n", rune(src[0])):
+				advance(1)
+			case src[0] == '#':
+				end := strings.IndexByte(src, '\n')
+				if end == -1 {
+					end = len(src)
+				}
+				advance(end)
+			case strings.HasPrefix(src, "/*"):
+				end := strings.Index(src, "*/")
+				if end == -1 {
+					return errors.Errorf("line %d, column %d: unterminated comment", line, col)
+				}
+				advance(end + 2)
+			default:
+				return nil
+			}
+		}
+
+		return nil
+	}
+
+	for {
+		if err := skip(); err != nil {
+			return nil, err
+		}
+
+		t := token{line: line, col: col}
+		if len(src) == 0 {
+			t.kind = tokenEOF
+			return append(tokens, t), nil
+		}
+
+		c := src[0]
+		n := 1
+		switch {
+		case strings.IndexByte("(){};", c) != -1:
+			t.kind = map[byte]tokenKind{'(': tokenOpenParen, ')': tokenCloseParen, '{': tokenOpenBrace, '}': tokenCloseBrace, ';': tokenSemicolon}[c]
+		case c == '"':
+			var b strings.Builder
+			for ; ; n++ {
+				if n >= len(src) || src[n] == '\n' {
+					return nil, t.errorf("unterminated string")
+				}
+
+				if src[n] == '"' {
+					n++
+					break
+				}
+
+				if src[n] == '\\' {
+					n++
+					if n >= len(src) {
+						return nil, t.errorf("unterminated string")
+					}
+
+					escaped, ok := map[byte]byte{'n': '\n', 't': '\t', 'b': '\b', 'r': '\r', '\\': '\\', '"': '"'}[src[n]]
+					if !ok {
+						return nil, t.errorf("invalid escape sequence '\\%c'", src[n])
+					}
+					b.WriteByte(escaped)
+					continue
+				}
+
+				b.WriteByte(src[n])
+			}
+			t.kind, t.value = tokenString, b.String()
+		case strings.HasPrefix(src, "0x"):
+			n = 2 + span(src[2:], isHex)
+			if n%2 != 0 {
+				return nil, t.errorf("invalid bytes '%s'", src[:n])
+			}
+			if _, err := hex.DecodeString(src[2:n]); err != nil {
+				return nil, t.errorf("invalid bytes '%s'", src[:n])
+			}
+			t.kind, t.value = tokenBytes, strings.ToLower(src[2:n])
+		case isDigit(c) || (c == '-' && len(src) > 1 && isDigit(src[1])):
+			n = 1 + span(src[1:], isDigit)
+			t.kind, t.value = tokenInt, src[:n]
+		case c == '@' || c == ':' || c == '%':
+			n = 1 + span(src[1:], isAnnotChar)
+			t.kind, t.value = tokenAnnot, src[:n]
+		case isLetter(c) || c == '_':
+			n = span(src, isIdentChar)
+			t.kind, t.value = tokenIdent, src[:n]
+		default:
+			return nil, t.errorf("unexpected character '%c'", c)
+		}
+
+		if n < len(src) && (t.kind == tokenInt || t.kind == tokenBytes || t.kind == tokenIdent) && (isIdentChar(src[n]) || src[n] == '"') {
+			return nil, t.errorf("unexpected character '%c'", src[n])
+		}
+
+		tokens = append(tokens, t)
+		advance(n)
+	}
+}
+
+func span(s string, f func(byte) bool) int {
+	for i := 0; i < len(s); i++ {
+		if !f(s[i]) {
+			return i
+		}
+	}
+
+	return len(s)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isHex(c byte) bool {
+	return isDigit(c) || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+func isIdentChar(c byte) bool {
+	return isLetter(c) || isDigit(c) || c == '_'
+}
+
+func isAnnotChar(c byte) bool {
+	return isIdentChar(c) || c == '.' || c == '%' || c == '@'
+}
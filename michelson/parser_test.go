@@ -0,0 +1,196 @@
+package michelson
+
+import (
+	"testing"
+
+	"github.com/goat-systems/go-tezos/v4/internal/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Parse(t *testing.T) {
+	cases := []struct {
+		name        string
+		input       string
+		wantErr     bool
+		containsErr string
+		want        string
+	}{
+		{
+			"is successful with contract",
+			`# a contract that stores its parameter
+			parameter (or (nat %increment) (unit %reset));
+			storage nat;
+			code { UNPAIR ;
+			       IF_LEFT { ADD } { DROP 2 ; PUSH nat 0 } ;
+			       NIL operation ; PAIR } ;`,
+			false,
+			"",
+			`[{"prim":"parameter","args":[{"prim":"or","args":[{"prim":"nat","annots":["%increment"]},{"prim":"unit","annots":["%reset"]}]}]},` +
+				`{"prim":"storage","args":[{"prim":"nat"}]},` +
+				`{"prim":"code","args":[[{"prim":"UNPAIR"},{"prim":"IF_LEFT","args":[[{"prim":"ADD"}],[{"prim":"DROP","args":[{"int":"2"}]},{"prim":"PUSH","args":[{"prim":"nat"},{"int":"0"}]}]]},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]]}]`,
+		},
+		{
+			"is successful with data",
+			`Pair "tz1L8fUQLuwRuywTZUP5JUw9LL3kJa8LMfoo" { Elt 0x00ff (Some -5) ; Elt 0x "esc\"aped\n" } /* comment */`,
+			false,
+			"",
+			`{"prim":"Pair","args":[{"string":"tz1L8fUQLuwRuywTZUP5JUw9LL3kJa8LMfoo"},[{"prim":"Elt","args":[{"bytes":"00ff"},{"prim":"Some","args":[{"int":"-5"}]}]},{"prim":"Elt","args":[{"bytes":""},{"string":"esc\"aped\n"}]}]]}`,
+		},
+		{
+			"is successful with annotations",
+			`CAR @first %field :type`,
+			false,
+			"",
+			`{"prim":"CAR","annots":["@first","%field",":type"]}`,
+		},
+		{
+			"is successful with a sequence",
+			`{}`,
+			false,
+			"",
+			`[]`,
+		},
+		{
+			"is successful with DIIP and DUUUP",
+			`{ DIIP { DROP } ; DUUUP @x }`,
+			false,
+			"",
+			`[[{"prim":"DIP","args":[{"int":"2"},[{"prim":"DROP"}]]}],[{"prim":"DUP","args":[{"int":"3"}],"annots":["@x"]}]]`,
+		},
+		{
+			"is successful with CADR",
+			`CDAR %x`,
+			false,
+			"",
+			`[{"prim":"CDR"},{"prim":"CAR","annots":["%x"]}]`,
+		},
+		{
+			"is successful with IF_SOME and IF_RIGHT",
+			`{ IF_SOME { DROP } { UNIT } ; IF_RIGHT { FAIL } {} }`,
+			false,
+			"",
+			`[[{"prim":"IF_NONE","args":[[{"prim":"UNIT"}],[{"prim":"DROP"}]]}],[{"prim":"IF_LEFT","args":[[],[[{"prim":"UNIT"},{"prim":"FAILWITH"}]]]}]]`,
+		},
+		{
+			"is successful with comparison macros",
+			`{ CMPEQ ; IFGT {} {} ; IFCMPLE {} {} ; ASSERT_CMPNEQ ; ASSERT_SOME }`,
+			false,
+			"",
+			`[[{"prim":"COMPARE"},{"prim":"EQ"}],` +
+				`[{"prim":"GT"},{"prim":"IF","args":[[],[]]}],` +
+				`[[{"prim":"COMPARE"},{"prim":"LE"}],{"prim":"IF","args":[[],[]]}],` +
+				`[[{"prim":"COMPARE"},{"prim":"NEQ"}],{"prim":"IF","args":[[],[[{"prim":"UNIT"},{"prim":"FAILWITH"}]]]}],` +
+				`[{"prim":"IF_NONE","args":[[[{"prim":"UNIT"},{"prim":"FAILWITH"}]],[]]}]]`,
+		},
+		{
+			"is successful with SET_CAR, PAPAIR and DUUUP",
+			`{ CADR @x ; SET_CAR ; PAPAIR ; DUUUP }`,
+			false,
+			"",
+			`[[{"prim":"CAR"},{"prim":"CDR","annots":["@x"]}],` +
+				`[{"prim":"CDR","annots":["@%%"]},{"prim":"SWAP"},{"prim":"PAIR","annots":["%","%@"]}],` +
+				`[{"prim":"DIP","args":[[{"prim":"PAIR"}]]},{"prim":"PAIR"}],` +
+				`[{"prim":"DUP","args":[{"int":"3"}]}]]`,
+		},
+		{
+			"is successful with SET_C[AD]+R",
+			`{ SET_CDR %x ; SET_CADR }`,
+			false,
+			"",
+			`[[{"prim":"DUP"},{"prim":"CDR","annots":["%x"]},{"prim":"DROP"},{"prim":"CAR","annots":["@%%"]},{"prim":"PAIR","annots":["%@","%x"]}],` +
+				`[{"prim":"DUP"},{"prim":"DIP","args":[[{"prim":"CAR","annots":["@%%"]},[{"prim":"CAR","annots":["@%%"]},{"prim":"PAIR","annots":["%@","%"]}]]]},` +
+				`{"prim":"CDR","annots":["@%%"]},{"prim":"SWAP"},{"prim":"PAIR","annots":["%@","%@"]}]]`,
+		},
+		{
+			"is successful with MAP_C[AD]+R",
+			`{ MAP_CDR { ADD } ; MAP_CAR %x { DROP } }`,
+			false,
+			"",
+			`[[{"prim":"DUP"},{"prim":"CDR"},[{"prim":"ADD"}],{"prim":"SWAP"},{"prim":"CAR","annots":["@%%"]},{"prim":"PAIR","annots":["%@","%"]}],` +
+				`[{"prim":"DUP"},{"prim":"CDR","annots":["@%%"]},{"prim":"DIP","args":[[{"prim":"CAR","annots":["@x"]},[{"prim":"DROP"}]]]},{"prim":"SWAP"},{"prim":"PAIR","annots":["%x","%@"]}]]`,
+		},
+		{
+			"is successful with P[AP]+IR and UNP[AP]+IR",
+			`{ PAPPAIIR @p %a %b %c %d ; UNPAPAIR ; UNPPAIPAIR }`,
+			false,
+			"",
+			`[[{"prim":"DIP","args":[[{"prim":"PAIR","annots":["%b","%c"]},{"prim":"PAIR","annots":["%","%d"]}]]},{"prim":"PAIR","annots":["%a","@p"]}],` +
+				`[{"prim":"UNPAIR"},{"prim":"DIP","args":[[{"prim":"UNPAIR"}]]}],` +
+				`[{"prim":"UNPAIR"},{"prim":"DIP","args":[[{"prim":"UNPAIR"}]]},{"prim":"UNPAIR"}]]`,
+		},
+		{
+			"handles unknown primitive",
+			`{ UNIT ; SET_CAR ; FOO }`,
+			true,
+			"line 1, column 20: unknown primitive 'FOO'",
+			"",
+		},
+		{
+			"handles invalid pair macro",
+			`PAAIR`,
+			true,
+			"unknown primitive 'PAAIR'",
+			"",
+		},
+		{
+			"handles MAP_CAR without a sequence",
+			`MAP_CAR DROP`,
+			true,
+			"macro MAP_CAR expects a sequence",
+			"",
+		},
+		{
+			"handles annotated UNP[AP]+IR",
+			`UNPAPAIR @x`,
+			true,
+			"macro UNPAPAIR does not take annotations",
+			"",
+		},
+		{
+			"handles macro with wrong arguments",
+			`{ UNIT ;
+			   DIIP }`,
+			true,
+			"line 2, column 7: macro DIIP expects 1 arguments but got 0",
+			"",
+		},
+		{
+			"handles unclosed sequence",
+			`code { UNIT`,
+			true,
+			"line 1, column 12: expected '}' but got end of input",
+			"",
+		},
+		{
+			"handles missing semicolon",
+			`{ UNIT } { UNIT }`,
+			true,
+			"expected end of input but got '{'",
+			"",
+		},
+		{
+			"handles unterminated string",
+			`"tacos`,
+			true,
+			"unterminated string",
+			"",
+		},
+		{
+			"handles invalid bytes",
+			`0xabc`,
+			true,
+			"invalid bytes '0xabc'",
+			"",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := Parse(tt.input)
+			testutils.CheckErr(t, tt.wantErr, tt.containsErr, err)
+			if !tt.wantErr {
+				assert.JSONEq(t, tt.want, string(*v))
+			}
+		})
+	}
+}
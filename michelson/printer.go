@@ -0,0 +1,179 @@
+package michelson
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// maxWidth is the width past which expressions are broken over several lines
+const maxWidth = 80
+
+/*
+Print prints Micheline JSON, such as the code of a contract from rpc.Client.ContractScript, as readable
+Michelson. Sequences that do not fit on a line are broken with one instruction per line, and the arguments of
+other primitives that do not fit are aligned one per line. A contract's sections are printed one per line, like in
+a .tz file. Macros are printed expanded.
+
+Example:
+	resp, err := client.ContractScript(rpc.ContractScriptInput{
+		BlockID:    &rpc.BlockIDHead{},
+		ContractID: "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg",
+	})
+	if err != nil {
+		return err
+	}
+
+	var script rpc.Script
+	if err := json.Unmarshal(resp.Body(), &script); err != nil {
+		return err
+	}
+
+	code, err := michelson.Print(*script.Code)
+*/
+func Print(micheline json.RawMessage) (string, error) {
+	var n node
+	if err := json.Unmarshal(micheline, &n); err != nil {
+		return "", errors.Wrap(err, "failed to print michelson")
+	}
+
+	if !isContract(n) {
+		return format(n, 0, false), nil
+	}
+
+	sections := make([]string, len(n.Seq))
+	for i, section := range n.Seq {
+		sections[i] = format(section, 0, false) + ";"
+	}
+
+	return strings.Join(sections, "\n"), nil
+}
+
+func isContract(n node) bool {
+	if !n.IsSeq || len(n.Seq) == 0 {
+		return false
+	}
+
+	for _, section := range n.Seq {
+		switch section.Prim {
+		case "parameter", "storage", "code", "view":
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// format prints n starting at column col. arg is whether n is the argument of a primitive, which needs parentheses if it has arguments or annotations.
+func format(n node, col int, arg bool) string {
+	line := flat(n, arg)
+	if col+len(line) <= maxWidth {
+		return line
+	}
+
+	var b strings.Builder
+	switch {
+	case n.IsSeq:
+		b.WriteString("{ ")
+		for i, item := range n.Seq {
+			if i > 0 {
+				b.WriteString(" ;\n")
+				b.WriteString(strings.Repeat(" ", col+2))
+			}
+			b.WriteString(format(item, col+2, false))
+		}
+		b.WriteString(" }")
+	case n.Prim != "":
+		parens := arg && (len(n.Args) > 0 || len(n.Annots) > 0)
+		if parens {
+			b.WriteByte('(')
+		}
+
+		head := strings.Join(append([]string{n.Prim}, n.Annots...), " ")
+		b.WriteString(head)
+
+		// arguments that do not fit are aligned under the first one
+		start := col + len(head) + 1
+		if parens {
+			start++
+		}
+		for i, a := range n.Args {
+			if i > 0 {
+				b.WriteString("\n")
+				b.WriteString(strings.Repeat(" ", start))
+			} else {
+				b.WriteByte(' ')
+			}
+			b.WriteString(format(a, start, true))
+		}
+
+		if parens {
+			b.WriteByte(')')
+		}
+	default:
+		return line
+	}
+
+	return b.String()
+}
+
+// flat prints n on a single line
+func flat(n node, arg bool) string {
+	switch {
+	case n.IsSeq:
+		if len(n.Seq) == 0 {
+			return "{}"
+		}
+
+		items := make([]string, len(n.Seq))
+		for i, item := range n.Seq {
+			items[i] = flat(item, false)
+		}
+		return "{ " + strings.Join(items, " ; ") + " }"
+	case n.Int != nil:
+		return *n.Int
+	case n.String != nil:
+		return quote(*n.String)
+	case n.Bytes != nil:
+		return "0x" + *n.Bytes
+	}
+
+	parts := append([]string{n.Prim}, n.Annots...)
+	for _, a := range n.Args {
+		parts = append(parts, flat(a, true))
+	}
+
+	if arg && len(parts) > 1 {
+		return "(" + strings.Join(parts, " ") + ")"
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func quote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, c := range s {
+		switch c {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\b':
+			b.WriteString(`\b`)
+		default:
+			b.WriteRune(c)
+		}
+	}
+	b.WriteByte('"')
+
+	return b.String()
+}
@@ -0,0 +1,110 @@
+package michelson
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/goat-systems/go-tezos/v4/internal/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Print(t *testing.T) {
+	cases := []struct {
+		name        string
+		input       string
+		wantErr     bool
+		containsErr string
+		want        string
+	}{
+		{
+			"is successful with contract",
+			`[{"prim":"parameter","args":[{"prim":"or","args":[{"prim":"nat","annots":["%increment"]},{"prim":"unit","annots":["%reset"]}]}]},` +
+				`{"prim":"storage","args":[{"prim":"nat"}]},` +
+				`{"prim":"code","args":[[{"prim":"UNPAIR"},{"prim":"IF_LEFT","args":[[{"prim":"ADD"}],[{"prim":"DROP","args":[{"int":"2"}]},{"prim":"PUSH","args":[{"prim":"nat"},{"int":"0"}]}]]},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]]}]`,
+			false,
+			"",
+			"parameter (or (nat %increment) (unit %reset));\n" +
+				"storage nat;\n" +
+				"code { UNPAIR ; IF_LEFT { ADD } { DROP 2 ; PUSH nat 0 } ; NIL operation ; PAIR };",
+		},
+		{
+			"is successful with data",
+			`{"prim":"Pair","args":[{"string":"esc\"aped\n"},[{"prim":"Elt","args":[{"bytes":"00ff"},{"prim":"Some","args":[{"int":"-5"}]}]}]]}`,
+			false,
+			"",
+			`Pair "esc\"aped\n" { Elt 0x00ff (Some -5) }`,
+		},
+		{
+			"is successful with line breaks",
+			`{"prim":"code","args":[[{"prim":"PUSH","args":[{"prim":"pair","args":[{"prim":"string","annots":["%description"]},{"prim":"address","annots":["%destination"]}]},{"prim":"Pair","args":[{"string":"a long description"},{"string":"tz1L8fUQLuwRuywTZUP5JUw9LL3kJa8LMfoo"}]}]},{"prim":"DROP"}]]}`,
+			false,
+			"",
+			"code { PUSH (pair (string %description) (address %destination))\n" +
+				"            (Pair \"a long description\" \"tz1L8fUQLuwRuywTZUP5JUw9LL3kJa8LMfoo\") ;\n" +
+				"       DROP }",
+		},
+		{
+			"is successful with annotations",
+			`{"prim":"CAR","annots":["@first","%field"]}`,
+			false,
+			"",
+			`CAR @first %field`,
+		},
+		{
+			"is successful with empty sequence",
+			`[]`,
+			false,
+			"",
+			`{}`,
+		},
+		{
+			"handles invalid json",
+			`{"prim":`,
+			true,
+			"failed to print michelson",
+			"",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := Print(json.RawMessage(tt.input))
+			testutils.CheckErr(t, tt.wantErr, tt.containsErr, err)
+			assert.Equal(t, tt.want, v)
+		})
+	}
+}
+
+func Test_Print_RoundTrip(t *testing.T) {
+	src := `parameter (pair (address %destination) (pair (nat %amount) (option %memo string)));
+storage (big_map address nat);
+code { UNPAIR ; UNPAIR ; DIP { UNPAIR ; DROP } ; DUP 3 ; DUP 2 ; GET ; IF_NONE { PUSH nat 0 } {} ;
+       DIG 2 ; ADD ; SOME ; SWAP ; UPDATE ; NIL operation ; PAIR ; CADR ; DIIP { FAIL } ; IFCMPEQ {} {} }`
+
+	parsed, err := Parse(src)
+	testutils.CheckErr(t, false, "", err)
+
+	printed, err := Print(*parsed)
+	testutils.CheckErr(t, false, "", err)
+
+	for _, line := range splitLines(printed) {
+		assert.LessOrEqual(t, len(line), maxWidth)
+	}
+
+	reparsed, err := Parse(printed)
+	testutils.CheckErr(t, false, "", err)
+	assert.JSONEq(t, string(*parsed), string(*reparsed))
+}
+
+func splitLines(s string) []string {
+	var lines []string
+	start := 0
+	for i := range s {
+		if s[i] == '\n' {
+			lines = append(lines, s[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(lines, s[start:])
+}
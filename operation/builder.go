@@ -0,0 +1,479 @@
+package operation
+
+import (
+	"encoding/hex"
+	"strconv"
+
+	"github.com/goat-systems/go-tezos/v4/forge"
+	tzcrypt "github.com/goat-systems/go-tezos/v4/internal/crypto"
+	"github.com/goat-systems/go-tezos/v4/keys"
+	"github.com/goat-systems/go-tezos/v4/rpc"
+	"github.com/pkg/errors"
+)
+
+const (
+	// gasMargin is added to the gas consumed in simulation, the same safety margin tezos-client uses.
+	gasMargin = 100
+
+	branchLength    = 32
+	signatureLength = 64
+)
+
+var (
+	// simulations skip signature checks but still need a well formed signature
+	simulationSignature = tzcrypt.B58cencode(make([]byte, signatureLength), []byte{4, 130, 43})
+)
+
+/*
+Builder turns manager operations (transactions, originations, delegations and reveals) into a signed operation
+for the signer it was created with. It fills in everything the node would otherwise make you compute by hand: the
+reveal of an unrevealed key, counters, gas and storage limits from a simulation, and the minimal fee.
+*/
+type Builder struct {
+	client rpc.IFace
+	signer keys.Signer
+	fees   FeeParameters
+}
+
+/*
+Operation is an operation built by a Builder that is ready to be signed.
+*/
+type Operation struct {
+	// The block hash the operation is forged against.
+	Branch string
+	// The contents with their counters, limits and fees set.
+	Contents rpc.Contents
+	// The hex encoded forged operation, without signature.
+	Forged string
+}
+
+/*
+NewBuilder returns a Builder that builds operations with client for the account of signer, which may be a *keys.Key,
+a *keys.RemoteSigner or any other keys.Signer.
+*/
+func NewBuilder(client rpc.IFace, signer keys.Signer) *Builder {
+	return &Builder{
+		client: client,
+		signer: signer,
+		fees:   DefaultFeeParameters,
+	}
+}
+
+// SetFeeParameters sets the minimal fee settings the builder pays fees for, which default to DefaultFeeParameters
+func (b *Builder) SetFeeParameters(fees FeeParameters) {
+	b.fees = fees
+}
+
+/*
+Build prepares contents for injection and forges them. Source, counter, fee, gas limit and storage limit are
+overwritten on every content. A reveal is prepended if the signer's manager is not revealed yet and the contents do
+not already start with one.
+
+Example:
+	op, err := operation.NewBuilder(client, key).Build(rpc.Content{
+		Kind:        rpc.TRANSACTION,
+		Amount:      rpc.NewMutez(1000000),
+		Destination: "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc",
+	})
+*/
+func (b *Builder) Build(contents ...rpc.Content) (Operation, error) {
+	head, constants, ops, counter, err := b.prepare(contents)
+	if err != nil {
+		return Operation{}, errors.Wrap(err, "failed to build operation")
+	}
+
+	for i := range ops {
+		ops[i].Counter = strconv.Itoa(counter + i + 1)
+	}
+
+	if err := b.simulate(head, constants, ops); err != nil {
+		return Operation{}, errors.Wrap(err, "failed to build operation")
+	}
+
+	if err := ValidateLimits(constants, ops...); err != nil {
+		return Operation{}, errors.Wrap(err, "failed to build operation")
+	}
+
+	if err := b.setFees(ops); err != nil {
+		return Operation{}, errors.Wrap(err, "failed to build operation")
+	}
+
+	forged, err := forge.Encode(head.Hash, ops...)
+	if err != nil {
+		return Operation{}, errors.Wrap(err, "failed to build operation")
+	}
+
+	return Operation{
+		Branch:   head.Hash,
+		Contents: ops,
+		Forged:   forged,
+	}, nil
+}
+
+/*
+BuildBatches prepares contents like Build, but splits them into as many operations as needed for each to fit the
+gas and size limits of the network (see Split). It is meant for contents too large for a single operation, such
+as the transactions of a payout. The operations use consecutive counters and must be injected in the order
+returned.
+
+Example:
+	ops, err := operation.NewBuilder(client, key).BuildBatches(payouts...)
+*/
+func (b *Builder) BuildBatches(contents ...rpc.Content) ([]Operation, error) {
+	head, constants, ops, counter, err := b.prepare(contents)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build operations")
+	}
+
+	if err := b.simulateBatches(head, constants, counter, ops); err != nil {
+		return nil, errors.Wrap(err, "failed to build operations")
+	}
+
+	// size every content with the highest counter and as if it paid for the branch and signature, so no group
+	// grows once its counters and fees are set
+	for i := range ops {
+		ops[i].Counter = strconv.Itoa(counter + len(ops))
+		if err := b.setFee(&ops[i], branchLength+signatureLength); err != nil {
+			return nil, errors.Wrap(err, "failed to build operations")
+		}
+	}
+
+	groups, err := Split(constants, counter, ops...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build operations")
+	}
+
+	var batches []Operation
+	for _, group := range groups {
+		if err := b.setFees(group); err != nil {
+			return nil, errors.Wrap(err, "failed to build operations")
+		}
+
+		forged, err := forge.Encode(head.Hash, group...)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to build operations")
+		}
+
+		batches = append(batches, Operation{
+			Branch:   head.Hash,
+			Contents: group,
+			Forged:   forged,
+		})
+	}
+
+	return batches, nil
+}
+
+/*
+Inject builds contents (see Build), signs the operation with the builder's signer and injects it. It returns the
+hash of the injected operation, which can be followed with rpc.Client.WaitForConfirmation.
+*/
+func (b *Builder) Inject(contents ...rpc.Content) (string, error) {
+	op, err := b.Build(contents...)
+	if err != nil {
+		return "", err
+	}
+
+	return b.inject(op)
+}
+
+/*
+InjectBatches builds contents into operations (see BuildBatches), then signs and injects them in order. It returns
+the hashes of the operations injected, which are also returned alongside an error if one of them fails to inject.
+*/
+func (b *Builder) InjectBatches(contents ...rpc.Content) ([]string, error) {
+	ops, err := b.BuildBatches(contents...)
+	if err != nil {
+		return nil, err
+	}
+
+	var hashes []string
+	for i, op := range ops {
+		hash, err := b.inject(op)
+		if err != nil {
+			return hashes, errors.Wrapf(err, "failed to inject operation %d of %d", i+1, len(ops))
+		}
+		hashes = append(hashes, hash)
+	}
+
+	return hashes, nil
+}
+
+func (b *Builder) inject(op Operation) (string, error) {
+	forged, err := hex.DecodeString(op.Forged)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign operation")
+	}
+
+	signature, err := b.signer.Sign(forged, keys.GenericOperationWatermark)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign operation")
+	}
+
+	_, hash, err := b.client.InjectionOperation(rpc.InjectionOperationInput{
+		Operation: signature.AppendToHex(op.Forged),
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to inject operation")
+	}
+
+	return hash, nil
+}
+
+/*
+prepare fetches what building contents depends on: the head to forge against, its constants and the current
+counter of the signer's manager. It returns the contents with their source set, preceded by a reveal if the manager
+still needs one.
+*/
+func (b *Builder) prepare(contents rpc.Contents) (*rpc.Block, rpc.Constants, rpc.Contents, int, error) {
+	if len(contents) == 0 {
+		return nil, rpc.Constants{}, nil, 0, errors.New("no contents")
+	}
+
+	_, head, err := b.client.Block(&rpc.BlockIDHead{})
+	if err != nil {
+		return nil, rpc.Constants{}, nil, 0, err
+	}
+	blockID := rpc.BlockIDHash(head.Hash)
+
+	_, constants, err := b.client.Constants(rpc.ConstantsInput{BlockID: &blockID})
+	if err != nil {
+		return nil, rpc.Constants{}, nil, 0, err
+	}
+
+	source := b.signer.Address()
+	_, manager, err := b.client.ContractManagerKey(rpc.ContractManagerKeyInput{
+		BlockID:    &blockID,
+		ContractID: source,
+	})
+	if err != nil {
+		return nil, rpc.Constants{}, nil, 0, err
+	}
+
+	var ops rpc.Contents
+	if manager == "" && contents[0].Kind != rpc.REVEAL {
+		pubKey := b.signer.PublicKey()
+		ops = append(ops, rpc.Content{
+			Kind:      rpc.REVEAL,
+			Source:    source,
+			PublicKey: pubKey.GetPublicKey(),
+		})
+	}
+
+	for _, content := range contents {
+		switch content.Kind {
+		case rpc.REVEAL, rpc.TRANSACTION, rpc.ORIGINATION, rpc.DELEGATION:
+		default:
+			return nil, rpc.Constants{}, nil, 0, errors.Errorf("'%s' is not a manager operation", content.Kind)
+		}
+
+		content.Metadata = nil
+		content.Source = source
+		ops = append(ops, content)
+	}
+
+	_, counter, err := b.client.ContractCounter(rpc.ContractCounterInput{
+		BlockID:    &blockID,
+		ContractID: source,
+	})
+	if err != nil {
+		return nil, rpc.Constants{}, nil, 0, err
+	}
+
+	return head, constants, ops, counter, nil
+}
+
+/*
+simulateBatches simulates ops in chunks small enough for each to run with the hard gas limit per operation, and
+sets their limits to what they actually used. A leading reveal is simulated with every chunk, since the contents
+after it depend on it.
+*/
+func (b *Builder) simulateBatches(head *rpc.Block, constants rpc.Constants, counter int, ops rpc.Contents) error {
+	var reveal rpc.Contents
+	if ops[0].Kind == rpc.REVEAL {
+		reveal, ops = ops[:1], ops[1:]
+	}
+
+	size := 1
+	if constants.HardGasLimitPerOperation > 0 {
+		size = constants.HardGasLimitPerBlock/constants.HardGasLimitPerOperation - len(reveal)
+	}
+	if size < 1 {
+		size = 1
+	}
+
+	for start := 0; start < len(ops) || start == 0; start += size {
+		end := start + size
+		if end > len(ops) {
+			end = len(ops)
+		}
+
+		chunk := append(append(rpc.Contents{}, reveal...), ops[start:end]...)
+		for i := range chunk {
+			chunk[i].Counter = strconv.Itoa(counter + i + 1)
+		}
+
+		if err := b.simulate(head, constants, chunk); err != nil {
+			return err
+		}
+
+		for i := range chunk[len(reveal):] {
+			ops[start+i].GasLimit = chunk[len(reveal)+i].GasLimit
+			ops[start+i].StorageLimit = chunk[len(reveal)+i].StorageLimit
+		}
+
+		if len(reveal) != 0 {
+			reveal[0].GasLimit = chunk[0].GasLimit
+			reveal[0].StorageLimit = chunk[0].StorageLimit
+		}
+	}
+
+	return nil
+}
+
+// simulate runs ops with the highest limits allowed and sets their limits to what they actually used
+func (b *Builder) simulate(head *rpc.Block, constants rpc.Constants, ops rpc.Contents) error {
+	gasLimit := constants.HardGasLimitPerOperation
+	if perOp := constants.HardGasLimitPerBlock / len(ops); perOp < gasLimit {
+		gasLimit = perOp
+	}
+
+	simulation := make(rpc.Contents, len(ops))
+	copy(simulation, ops)
+	for i := range simulation {
+		simulation[i].Fee = rpc.NewMutez(0)
+		simulation[i].GasLimit = strconv.Itoa(gasLimit)
+		simulation[i].StorageLimit = strconv.Itoa(constants.HardStorageLimitPerOperation)
+	}
+
+	blockID := rpc.BlockIDHash(head.Hash)
+	_, result, err := b.client.RunOperation(rpc.RunOperationInput{
+		BlockID: &blockID,
+		Operation: rpc.RunOperation{
+			Operation: rpc.Operations{
+				Branch:    head.Hash,
+				Contents:  simulation,
+				Signature: simulationSignature,
+			},
+			ChainID: head.ChainID,
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to simulate operation")
+	}
+
+	if len(result.Contents) != len(ops) {
+		return errors.Errorf("failed to simulate operation: expected %d results but got %d", len(ops), len(result.Contents))
+	}
+
+	for i, content := range result.Contents {
+		if content.Metadata == nil || content.Metadata.OperationResults == nil {
+			return errors.Errorf("failed to simulate operation: missing result for content %d", i)
+		}
+
+		res := content.Metadata.OperationResults
+		if res.Status != "applied" {
+			if len(res.Errors) == 0 {
+				return errors.Errorf("failed to simulate operation: content %d (%s) has status '%s'", i, content.Kind, res.Status)
+			}
+			return errors.Wrapf(rpc.Errors(res.Errors), "failed to simulate operation: content %d (%s) has status '%s'", i, content.Kind, res.Status)
+		}
+
+		gas, storage, err := consumed(content.Metadata, constants.OriginationSize)
+		if err != nil {
+			return errors.Wrap(err, "failed to simulate operation")
+		}
+
+		ops[i].GasLimit = strconv.Itoa(gas + gasMargin)
+		ops[i].StorageLimit = strconv.Itoa(storage)
+	}
+
+	return nil
+}
+
+// consumed sums the gas and storage burned by a content and the internal operations it triggered
+func consumed(metadata *rpc.ContentsMetadata, originationSize int) (int, int, error) {
+	gas, err := atoi(metadata.OperationResults.ConsumedGas)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to parse consumed gas")
+	}
+
+	storage, err := atoi(metadata.OperationResults.PaidStorageSizeDiff)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to parse paid storage size diff")
+	}
+
+	storage += originationSize * len(metadata.OperationResults.OriginatedContracts)
+	if metadata.OperationResults.AllocatedDestinationContract {
+		storage += originationSize
+	}
+
+	for _, internal := range metadata.InternalOperationResult {
+		if internal.Result.Status != "applied" {
+			if len(internal.Result.Errors) == 0 {
+				return 0, 0, errors.Errorf("internal %s has status '%s'", internal.Kind, internal.Result.Status)
+			}
+			return 0, 0, errors.Wrapf(rpc.Errors(internal.Result.Errors), "internal %s has status '%s'", internal.Kind, internal.Result.Status)
+		}
+
+		g, err := atoi(internal.Result.ConsumedGas)
+		if err != nil {
+			return 0, 0, errors.Wrap(err, "failed to parse consumed gas")
+		}
+
+		s, err := atoi(internal.Result.PaidStorageSizeDiff)
+		if err != nil {
+			return 0, 0, errors.Wrap(err, "failed to parse paid storage size diff")
+		}
+
+		gas += g
+		storage += s + originationSize*len(internal.Result.OriginatedContracts)
+		if internal.Result.AllocatedDestinationContract {
+			storage += originationSize
+		}
+	}
+
+	return gas, storage, nil
+}
+
+/*
+setFees sets the minimal fee on every content, so each content pays for its own gas and bytes and the first one
+also pays for the branch and signature.
+*/
+func (b *Builder) setFees(ops rpc.Contents) error {
+	for i := range ops {
+		extra := 0
+		if i == 0 {
+			extra = branchLength + signatureLength
+		}
+
+		if err := b.setFee(&ops[i], extra); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setFee sets the minimal fee for content and extra bytes. Since the fee is part of the forged size, it is raised until it covers itself.
+func (b *Builder) setFee(content *rpc.Content, extra int) error {
+	gas, err := atoi(content.GasLimit)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse gas limit")
+	}
+
+	fee := 0
+	for {
+		content.Fee = rpc.NewMutez(int64(fee))
+		forged, err := forge.Encode("", *content)
+		if err != nil {
+			return errors.Wrap(err, "failed to compute fee")
+		}
+
+		needed := b.fees.fee(len(forged)/2+extra, gas)
+		if needed <= fee {
+			return nil
+		}
+		fee = needed
+	}
+}
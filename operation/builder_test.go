@@ -0,0 +1,363 @@
+package operation
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/goat-systems/go-tezos/v4/forge"
+	"github.com/goat-systems/go-tezos/v4/internal/testutils"
+	"github.com/goat-systems/go-tezos/v4/keys"
+	"github.com/goat-systems/go-tezos/v4/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	mockBranch    = "BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1"
+	mockConstants = `{"max_operation_data_length":16384,"hard_gas_limit_per_operation":"1040000","hard_gas_limit_per_block":"10400000","origination_size":257,"cost_per_byte":"250","hard_storage_limit_per_operation":"60000"}`
+	// the destination mockNode treats as an empty implicit account
+	mockEmptyAccount = "tz1S82rGFZK8cVbNDpP1Hf9VhTUa4W8oc2WV"
+)
+
+// mockNode simulates the RPCs a Builder relies on
+type mockNode struct {
+	managerKey string
+	counter    int
+	// results maps a content kind to the operation result simulating it returns
+	results   map[rpc.Kind]rpc.OperationResults
+	simulated rpc.RunOperation
+	// simulations counts the calls to run_operation
+	simulations int
+	injected    []string
+}
+
+func newMockNode(managerKey string) *mockNode {
+	return &mockNode{
+		managerKey: managerKey,
+		counter:    10,
+		results: map[rpc.Kind]rpc.OperationResults{
+			rpc.REVEAL:      {Status: "applied", ConsumedGas: "1000"},
+			rpc.TRANSACTION: {Status: "applied", ConsumedGas: "10207"},
+			rpc.DELEGATION:  {Status: "applied", ConsumedGas: "1000"},
+		},
+	}
+}
+
+func (m *mockNode) handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/chains/main/blocks/head":
+			fmt.Fprintf(w, `{"hash":"%s","chain_id":"NetXdQprcVkpaWU","header":{"level":100}}`, mockBranch)
+		case strings.HasSuffix(r.URL.Path, "/context/constants"):
+			fmt.Fprint(w, mockConstants)
+		case strings.HasSuffix(r.URL.Path, "/manager_key"):
+			fmt.Fprint(w, m.managerKey)
+		case strings.HasSuffix(r.URL.Path, "/counter"):
+			fmt.Fprintf(w, `"%d"`, m.counter)
+		case strings.HasSuffix(r.URL.Path, "/helpers/scripts/run_operation"):
+			json.NewDecoder(r.Body).Decode(&m.simulated)
+			m.simulations++
+
+			result := m.simulated.Operation
+			for i, content := range result.Contents {
+				res := m.results[content.Kind]
+				if content.Kind == rpc.TRANSACTION && content.Destination == mockEmptyAccount {
+					res.AllocatedDestinationContract = true
+				}
+				result.Contents[i].Metadata = &rpc.ContentsMetadata{OperationResults: &res}
+			}
+
+			v, _ := json.Marshal(result)
+			w.Write(v)
+		case r.URL.Path == "/injection/operation":
+			var injected string
+			json.NewDecoder(r.Body).Decode(&injected)
+			m.injected = append(m.injected, injected)
+			fmt.Fprint(w, `"ooYSSxYcgreJQtrzxqfBdEG8Kbvb3AfBqJTvHKvXiryBbwPbbbx"`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func Test_Build(t *testing.T) {
+	key, err := keys.FromHex("7579c4881fb998d043417b7c04582aa15179f125c5303e1ee56a9678034d95b0", keys.Ed25519)
+	testutils.CheckErr(t, false, "", err)
+
+	transaction := rpc.Content{
+		Kind:        rpc.TRANSACTION,
+		Amount:      rpc.NewMutez(1000000),
+		Destination: mockEmptyAccount,
+	}
+
+	t.Run("reveals unrevealed key", func(t *testing.T) {
+		node := newMockNode("null")
+		server := httptest.NewServer(node.handler())
+		defer server.Close()
+
+		client, err := rpc.New(server.URL)
+		testutils.CheckErr(t, false, "", err)
+
+		op, err := NewBuilder(client, key).Build(transaction)
+		testutils.CheckErr(t, false, "", err)
+
+		assert.Equal(t, mockBranch, op.Branch)
+		assert.Len(t, op.Contents, 2)
+
+		reveal := op.Contents[0]
+		assert.Equal(t, rpc.REVEAL, reveal.Kind)
+		assert.Equal(t, key.PubKey.GetPublicKey(), reveal.PublicKey)
+		assert.Equal(t, key.PubKey.GetAddress(), reveal.Source)
+		assert.Equal(t, "11", reveal.Counter)
+		assert.Equal(t, "1100", reveal.GasLimit)
+		assert.Equal(t, "0", reveal.StorageLimit)
+		// 100 + (100 * 1100 + 1000 * (61 + 32 + 64)) / 1000
+		assert.Equal(t, "367", reveal.Fee.String())
+
+		tx := op.Contents[1]
+		assert.Equal(t, key.PubKey.GetAddress(), tx.Source)
+		assert.Equal(t, "12", tx.Counter)
+		assert.Equal(t, "10307", tx.GasLimit)
+		assert.Equal(t, "257", tx.StorageLimit)
+		// 100 + (100 * 10307 + 1000 * 55) / 1000, rounded up
+		assert.Equal(t, "1186", tx.Fee.String())
+
+		forged, err := forge.Encode(mockBranch, op.Contents...)
+		testutils.CheckErr(t, false, "", err)
+		assert.Equal(t, forged, op.Forged)
+
+		// the simulation runs with the highest limits and no fee
+		assert.Equal(t, "NetXdQprcVkpaWU", node.simulated.ChainID)
+		assert.Equal(t, "0", node.simulated.Operation.Contents[1].Fee.String())
+		assert.Equal(t, "1040000", node.simulated.Operation.Contents[1].GasLimit)
+		assert.Equal(t, "60000", node.simulated.Operation.Contents[1].StorageLimit)
+	})
+
+	t.Run("skips reveal for revealed key", func(t *testing.T) {
+		node := newMockNode(fmt.Sprintf(`"%s"`, key.PubKey.GetPublicKey()))
+		server := httptest.NewServer(node.handler())
+		defer server.Close()
+
+		client, err := rpc.New(server.URL)
+		testutils.CheckErr(t, false, "", err)
+
+		op, err := NewBuilder(client, key).Build(transaction, rpc.Content{
+			Kind:     rpc.DELEGATION,
+			Delegate: "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc",
+		})
+		testutils.CheckErr(t, false, "", err)
+
+		assert.Len(t, op.Contents, 2)
+		assert.Equal(t, rpc.TRANSACTION, op.Contents[0].Kind)
+		assert.Equal(t, "11", op.Contents[0].Counter)
+		assert.Equal(t, rpc.DELEGATION, op.Contents[1].Kind)
+		assert.Equal(t, "12", op.Contents[1].Counter)
+		assert.Equal(t, "1100", op.Contents[1].GasLimit)
+		assert.Equal(t, "0", op.Contents[1].StorageLimit)
+	})
+
+	t.Run("uses fee parameters", func(t *testing.T) {
+		node := newMockNode(fmt.Sprintf(`"%s"`, key.PubKey.GetPublicKey()))
+		server := httptest.NewServer(node.handler())
+		defer server.Close()
+
+		client, err := rpc.New(server.URL)
+		testutils.CheckErr(t, false, "", err)
+
+		builder := NewBuilder(client, key)
+		builder.SetFeeParameters(FeeParameters{MinimalFees: 1000})
+
+		op, err := builder.Build(transaction)
+		testutils.CheckErr(t, false, "", err)
+		assert.Equal(t, "1000", op.Contents[0].Fee.String())
+	})
+
+	t.Run("handles failed simulation", func(t *testing.T) {
+		node := newMockNode("null")
+		node.results[rpc.TRANSACTION] = rpc.OperationResults{
+			Status: "failed",
+			Errors: []rpc.ResultError{{Kind: "temporary", ID: "proto.008-PtEdo2Zk.contract.balance_too_low"}},
+		}
+		server := httptest.NewServer(node.handler())
+		defer server.Close()
+
+		client, err := rpc.New(server.URL)
+		testutils.CheckErr(t, false, "", err)
+
+		_, err = NewBuilder(client, key).Build(transaction)
+		testutils.CheckErr(t, true, "content 1 (transaction) has status 'failed': rpc error (temporary): proto.008-PtEdo2Zk.contract.balance_too_low", err)
+		assert.True(t, rpc.IsBalanceTooLow(err))
+	})
+
+	t.Run("handles non manager operation", func(t *testing.T) {
+		node := newMockNode("null")
+		server := httptest.NewServer(node.handler())
+		defer server.Close()
+
+		client, err := rpc.New(server.URL)
+		testutils.CheckErr(t, false, "", err)
+
+		_, err = NewBuilder(client, key).Build(rpc.Content{Kind: rpc.ENDORSEMENT, Level: 10})
+		testutils.CheckErr(t, true, "'endorsement' is not a manager operation", err)
+	})
+
+	t.Run("handles no contents", func(t *testing.T) {
+		_, err = NewBuilder(nil, key).Build()
+		testutils.CheckErr(t, true, "no contents", err)
+	})
+}
+
+func Test_Inject(t *testing.T) {
+	key, err := keys.FromHex("7579c4881fb998d043417b7c04582aa15179f125c5303e1ee56a9678034d95b0", keys.Ed25519)
+	testutils.CheckErr(t, false, "", err)
+
+	node := newMockNode("null")
+	server := httptest.NewServer(node.handler())
+	defer server.Close()
+
+	client, err := rpc.New(server.URL)
+	testutils.CheckErr(t, false, "", err)
+
+	hash, err := NewBuilder(client, key).Inject(rpc.Content{
+		Kind:        rpc.TRANSACTION,
+		Amount:      rpc.NewMutez(1000000),
+		Destination: "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc",
+	})
+	testutils.CheckErr(t, false, "", err)
+	assert.Equal(t, "ooYSSxYcgreJQtrzxqfBdEG8Kbvb3AfBqJTvHKvXiryBbwPbbbx", hash)
+
+	assert.Len(t, node.injected, 1)
+	forged, signature := node.injected[0][:len(node.injected[0])-signatureLength*2], node.injected[0][len(node.injected[0])-signatureLength*2:]
+	branch, contents, _, err := forge.Decode(forged)
+	testutils.CheckErr(t, false, "", err)
+	assert.Equal(t, mockBranch, branch)
+	assert.Len(t, contents, 2)
+
+	sig, err := key.SignHex(forged)
+	testutils.CheckErr(t, false, "", err)
+	assert.Equal(t, sig.ToHex(), signature)
+}
+
+// recordingSigner is a keys.Signer of its own that records the watermarks it signs with
+type recordingSigner struct {
+	keys.Signer
+	watermarks []keys.Watermark
+}
+
+func (r *recordingSigner) Sign(msg []byte, watermark keys.Watermark) (keys.Signature, error) {
+	r.watermarks = append(r.watermarks, watermark)
+	return r.Signer.Sign(msg, watermark)
+}
+
+func Test_Inject_Signer(t *testing.T) {
+	key, err := keys.FromHex("7579c4881fb998d043417b7c04582aa15179f125c5303e1ee56a9678034d95b0", keys.Secp256k1)
+	testutils.CheckErr(t, false, "", err)
+	signer := &recordingSigner{Signer: key}
+
+	node := newMockNode("null")
+	server := httptest.NewServer(node.handler())
+	defer server.Close()
+
+	client, err := rpc.New(server.URL)
+	testutils.CheckErr(t, false, "", err)
+
+	_, err = NewBuilder(client, signer).Inject(rpc.Content{
+		Kind:        rpc.TRANSACTION,
+		Amount:      rpc.NewMutez(1000000),
+		Destination: "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc",
+	})
+	testutils.CheckErr(t, false, "", err)
+	assert.Equal(t, []keys.Watermark{keys.GenericOperationWatermark}, signer.watermarks)
+
+	assert.Len(t, node.injected, 1)
+	forged, signature := node.injected[0][:len(node.injected[0])-signatureLength*2], node.injected[0][len(node.injected[0])-signatureLength*2:]
+	_, contents, _, err := forge.Decode(forged)
+	testutils.CheckErr(t, false, "", err)
+	assert.Equal(t, key.PubKey.GetAddress(), contents[0].Source)
+	assert.Equal(t, key.PubKey.GetPublicKey(), contents[0].PublicKey)
+
+	ok, err := key.PubKey.VerifyHex(forged, (&keys.Signature{Bytes: mustDecodeHex(t, signature)}).ToBase58())
+	testutils.CheckErr(t, false, "", err)
+	assert.True(t, ok)
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	v, err := hex.DecodeString(s)
+	testutils.CheckErr(t, false, "", err)
+	return v
+}
+
+func Test_BuildBatches(t *testing.T) {
+	key, err := keys.FromHex("7579c4881fb998d043417b7c04582aa15179f125c5303e1ee56a9678034d95b0", keys.Ed25519)
+	testutils.CheckErr(t, false, "", err)
+
+	payouts := make([]rpc.Content, 400)
+	for i := range payouts {
+		payouts[i] = rpc.Content{
+			Kind:        rpc.TRANSACTION,
+			Amount:      rpc.NewMutez(1000000),
+			Destination: mockEmptyAccount,
+		}
+	}
+
+	node := newMockNode("null")
+	server := httptest.NewServer(node.handler())
+	defer server.Close()
+
+	client, err := rpc.New(server.URL)
+	testutils.CheckErr(t, false, "", err)
+
+	ops, err := NewBuilder(client, key).BuildBatches(payouts...)
+	testutils.CheckErr(t, false, "", err)
+	assert.Len(t, ops, 2)
+
+	// 10 contents fit the block gas limit at the hard gas limit per operation, one of which is the reveal
+	assert.Equal(t, 45, node.simulations)
+	assert.Equal(t, rpc.REVEAL, node.simulated.Operation.Contents[0].Kind)
+
+	assert.Equal(t, rpc.REVEAL, ops[0].Contents[0].Kind)
+	assert.Equal(t, "367", ops[0].Contents[0].Fee.String())
+	assert.Equal(t, "1100", ops[0].Contents[0].GasLimit)
+
+	counter := 10
+	for _, op := range ops {
+		assert.Equal(t, mockBranch, op.Branch)
+		assert.LessOrEqual(t, len(op.Forged)/2+signatureLength, 16384)
+
+		forged, err := forge.Encode(mockBranch, op.Contents...)
+		testutils.CheckErr(t, false, "", err)
+		assert.Equal(t, forged, op.Forged)
+
+		for i, content := range op.Contents {
+			counter++
+			assert.Equal(t, strconv.Itoa(counter), content.Counter)
+			if content.Kind != rpc.TRANSACTION {
+				continue
+			}
+
+			assert.Equal(t, "10307", content.GasLimit)
+			assert.Equal(t, "257", content.StorageLimit)
+			// 100 + (100 * 10307 + 1000 * 55) / 1000, rounded up, where counters from 128 take a byte more
+			fee := 1186
+			if counter >= 128 {
+				fee++
+			}
+			if i == 0 {
+				// the first content also pays for the branch and signature
+				fee += branchLength + signatureLength
+			}
+			assert.Equal(t, strconv.Itoa(fee), content.Fee.String())
+		}
+	}
+	assert.Equal(t, 411, counter)
+
+	hashes, err := NewBuilder(client, key).InjectBatches(payouts...)
+	testutils.CheckErr(t, false, "", err)
+	assert.Len(t, hashes, 2)
+	assert.Len(t, node.injected, 2)
+}
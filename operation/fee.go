@@ -0,0 +1,103 @@
+package operation
+
+import (
+	"strconv"
+
+	"github.com/goat-systems/go-tezos/v4/rpc"
+	"github.com/pkg/errors"
+)
+
+/*
+FeeParameters are the minimal fee settings a baker filters operations with. A baker only includes an operation
+paying at least MinimalFees mutez plus MinimalNanotezPerGasUnit for every unit of gas limit and
+MinimalNanotezPerByte for every byte of the signed operation.
+*/
+type FeeParameters struct {
+	MinimalFees              int
+	MinimalNanotezPerGasUnit int
+	MinimalNanotezPerByte    int
+}
+
+// DefaultFeeParameters are the minimal fee settings bakers run with unless configured otherwise
+var DefaultFeeParameters = FeeParameters{
+	MinimalFees:              100,
+	MinimalNanotezPerGasUnit: 100,
+	MinimalNanotezPerByte:    1000,
+}
+
+/*
+EstimateFee returns the minimal fee in mutez a baker with DefaultFeeParameters accepts for an operation. forged
+is the hex encoded operation as returned by forge.Encode, without signature, and gasLimit is the sum of the gas
+limits of its contents.
+*/
+func EstimateFee(forged string, gasLimit int) (int, error) {
+	return DefaultFeeParameters.EstimateFee(forged, gasLimit)
+}
+
+// EstimateFee returns the minimal fee in mutez for an operation under f. See EstimateFee.
+func (f FeeParameters) EstimateFee(forged string, gasLimit int) (int, error) {
+	if len(forged)%2 != 0 {
+		return 0, errors.New("failed to estimate fee: forged operation is not hex encoded")
+	}
+
+	return f.fee(len(forged)/2+signatureLength, gasLimit), nil
+}
+
+// fee returns the minimal fee for size bytes and gasLimit units of gas, rounded up to the next mutez
+func (f FeeParameters) fee(size, gasLimit int) int {
+	nanotez := f.MinimalNanotezPerGasUnit*gasLimit + f.MinimalNanotezPerByte*size
+	return f.MinimalFees + (nanotez+999)/1000
+}
+
+/*
+ValidateLimits checks the gas and storage limits of contents against the hard limits of the network. Every
+content must fit the per operation limits, and their gas together must fit in a block.
+*/
+func ValidateLimits(constants rpc.Constants, contents ...rpc.Content) error {
+	var total int
+	for i, content := range contents {
+		gas, err := validateLimits(constants, i, content)
+		if err != nil {
+			return err
+		}
+
+		total += gas
+	}
+
+	if total > constants.HardGasLimitPerBlock {
+		return errors.Errorf("total gas limit %d exceeds the hard gas limit per block %d", total, constants.HardGasLimitPerBlock)
+	}
+
+	return nil
+}
+
+// validateLimits checks the limits of the i-th content against the per operation limits and returns its gas limit
+func validateLimits(constants rpc.Constants, i int, content rpc.Content) (int, error) {
+	gas, err := atoi(content.GasLimit)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid gas limit for content %d", i)
+	}
+
+	if gas > constants.HardGasLimitPerOperation {
+		return 0, errors.Errorf("gas limit %d of content %d exceeds the hard gas limit per operation %d", gas, i, constants.HardGasLimitPerOperation)
+	}
+
+	storage, err := atoi(content.StorageLimit)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid storage limit for content %d", i)
+	}
+
+	if storage > constants.HardStorageLimitPerOperation {
+		return 0, errors.Errorf("storage limit %d of content %d exceeds the hard storage limit per operation %d", storage, i, constants.HardStorageLimitPerOperation)
+	}
+
+	return gas, nil
+}
+
+func atoi(v string) (int, error) {
+	if v == "" {
+		return 0, nil
+	}
+
+	return strconv.Atoi(v)
+}
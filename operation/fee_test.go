@@ -0,0 +1,145 @@
+package operation
+
+import (
+	"testing"
+
+	"github.com/goat-systems/go-tezos/v4/forge"
+	"github.com/goat-systems/go-tezos/v4/internal/testutils"
+	"github.com/goat-systems/go-tezos/v4/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_EstimateFee(t *testing.T) {
+	// 32 bytes of branch and 55 bytes of transaction
+	forged, err := forge.Encode(mockBranch, rpc.Content{
+		Kind:         rpc.TRANSACTION,
+		Source:       "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc",
+		Fee:          rpc.NewMutez(1186),
+		Counter:      "12",
+		GasLimit:     "10307",
+		StorageLimit: "257",
+		Amount:       rpc.NewMutez(1000000),
+		Destination:  mockEmptyAccount,
+	})
+	testutils.CheckErr(t, false, "", err)
+	assert.Len(t, forged, (32+55)*2)
+
+	cases := []struct {
+		name        string
+		parameters  FeeParameters
+		forged      string
+		gasLimit    int
+		wantErr     bool
+		containsErr string
+		fee         int
+	}{
+		{
+			"is successful with default parameters",
+			DefaultFeeParameters,
+			forged,
+			10307,
+			false,
+			"",
+			// 100 + (100 * 10307 + 1000 * (87 + 64)) / 1000, rounded up
+			1282,
+		},
+		{
+			"is successful with overridden parameters",
+			FeeParameters{MinimalFees: 0, MinimalNanotezPerGasUnit: 200, MinimalNanotezPerByte: 500},
+			forged,
+			10000,
+			false,
+			"",
+			// (200 * 10000 + 500 * (87 + 64)) / 1000, rounded up
+			2076,
+		},
+		{
+			"handles invalid hex",
+			DefaultFeeParameters,
+			"abc",
+			10000,
+			true,
+			"not hex encoded",
+			0,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			fee, err := tt.parameters.EstimateFee(tt.forged, tt.gasLimit)
+			testutils.CheckErr(t, tt.wantErr, tt.containsErr, err)
+			assert.Equal(t, tt.fee, fee)
+		})
+	}
+
+	fee, err := EstimateFee(forged, 10307)
+	testutils.CheckErr(t, false, "", err)
+	assert.Equal(t, 1282, fee)
+}
+
+func Test_ValidateLimits(t *testing.T) {
+	constants := rpc.Constants{
+		HardGasLimitPerOperation:     1040000,
+		HardGasLimitPerBlock:         2000000,
+		HardStorageLimitPerOperation: 60000,
+	}
+
+	cases := []struct {
+		name        string
+		contents    []rpc.Content
+		wantErr     bool
+		containsErr string
+	}{
+		{
+			"is successful",
+			[]rpc.Content{
+				{Kind: rpc.TRANSACTION, GasLimit: "1040000", StorageLimit: "60000"},
+				{Kind: rpc.TRANSACTION, GasLimit: "10307", StorageLimit: "257"},
+			},
+			false,
+			"",
+		},
+		{
+			"handles gas limit per operation",
+			[]rpc.Content{
+				{Kind: rpc.TRANSACTION, GasLimit: "10307", StorageLimit: "257"},
+				{Kind: rpc.TRANSACTION, GasLimit: "1040001", StorageLimit: "0"},
+			},
+			true,
+			"gas limit 1040001 of content 1 exceeds the hard gas limit per operation 1040000",
+		},
+		{
+			"handles storage limit per operation",
+			[]rpc.Content{
+				{Kind: rpc.ORIGINATION, GasLimit: "10307", StorageLimit: "60001"},
+			},
+			true,
+			"storage limit 60001 of content 0 exceeds the hard storage limit per operation 60000",
+		},
+		{
+			"handles gas limit per block",
+			[]rpc.Content{
+				{Kind: rpc.TRANSACTION, GasLimit: "1000000", StorageLimit: "0"},
+				{Kind: rpc.TRANSACTION, GasLimit: "1000000", StorageLimit: "0"},
+				{Kind: rpc.TRANSACTION, GasLimit: "1", StorageLimit: "0"},
+			},
+			true,
+			"total gas limit 2000001 exceeds the hard gas limit per block 2000000",
+		},
+		{
+			"handles invalid gas limit",
+			[]rpc.Content{
+				{Kind: rpc.TRANSACTION, GasLimit: "lots", StorageLimit: "0"},
+			},
+			true,
+			"invalid gas limit for content 0",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateLimits(constants, tt.contents...)
+			testutils.CheckErr(t, tt.wantErr, tt.containsErr, err)
+		})
+	}
+}
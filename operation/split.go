@@ -0,0 +1,124 @@
+package operation
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/goat-systems/go-tezos/v4/forge"
+	"github.com/goat-systems/go-tezos/v4/rpc"
+	"github.com/pkg/errors"
+)
+
+/*
+Split packs contents of a single source into as few operation groups as possible, so that the gas limits of each
+group fit in HardGasLimitPerBlock and each signed group fits in MaxOperationDataLength. Every content must already
+have its fee, gas limit and storage limit set, and must fit the per operation limits on its own.
+
+Counters are assigned across the groups in the order they are returned, starting at counter+1, so the groups
+must be injected in that order. A reveal at the start of contents is kept at the start of the first group. The
+order of the remaining contents is kept within a group but not across groups.
+
+Example:
+	groups, err := operation.Split(constants, counter, payouts...)
+*/
+func Split(constants rpc.Constants, counter int, contents ...rpc.Content) ([]rpc.Contents, error) {
+	if len(contents) == 0 {
+		return nil, nil
+	}
+
+	maxSize := constants.MaxOperationDataLength - branchLength - signatureLength
+	items := make([]splitItem, len(contents))
+	for i, content := range contents {
+		gas, err := validateLimits(constants, i, content)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to split operations")
+		}
+
+		if gas > constants.HardGasLimitPerBlock {
+			return nil, errors.Errorf("failed to split operations: gas limit %d of content %d exceeds the hard gas limit per block %d", gas, i, constants.HardGasLimitPerBlock)
+		}
+
+		// the counter only shrinks once it is assigned, so the size measured with the highest counter is an upper bound
+		content.Counter = strconv.Itoa(counter + len(contents))
+		forged, err := forge.Encode("", content)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to split operations: failed to forge content %d", i)
+		}
+
+		items[i] = splitItem{index: i, gas: gas, size: len(forged) / 2}
+		if items[i].size > maxSize {
+			return nil, errors.Errorf("failed to split operations: content %d is too large for an operation", i)
+		}
+	}
+
+	var groups []splitGroup
+	if contents[0].Kind == rpc.REVEAL {
+		groups = append(groups, splitGroup{gas: items[0].gas, size: items[0].size, items: []int{0}})
+		items = items[1:]
+	}
+
+	// first fit decreasing, by whichever limit a content takes the largest share of
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].weight(constants.HardGasLimitPerBlock, maxSize) > items[j].weight(constants.HardGasLimitPerBlock, maxSize)
+	})
+
+	for _, item := range items {
+		placed := false
+		for g := range groups {
+			if groups[g].gas+item.gas <= constants.HardGasLimitPerBlock && groups[g].size+item.size <= maxSize {
+				groups[g].add(item)
+				placed = true
+				break
+			}
+		}
+
+		if !placed {
+			var group splitGroup
+			group.add(item)
+			groups = append(groups, group)
+		}
+	}
+
+	var out []rpc.Contents
+	for _, group := range groups {
+		sort.Ints(group.items)
+
+		ops := make(rpc.Contents, len(group.items))
+		for i, index := range group.items {
+			counter++
+			ops[i] = contents[index]
+			ops[i].Counter = strconv.Itoa(counter)
+		}
+		out = append(out, ops)
+	}
+
+	return out, nil
+}
+
+type splitItem struct {
+	index int
+	gas   int
+	size  int
+}
+
+func (s splitItem) weight(maxGas, maxSize int) float64 {
+	gas := float64(s.gas) / float64(maxGas)
+	size := float64(s.size) / float64(maxSize)
+	if gas > size {
+		return gas
+	}
+
+	return size
+}
+
+type splitGroup struct {
+	gas   int
+	size  int
+	items []int
+}
+
+func (s *splitGroup) add(item splitItem) {
+	s.gas += item.gas
+	s.size += item.size
+	s.items = append(s.items, item.index)
+}
@@ -0,0 +1,138 @@
+package operation
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/goat-systems/go-tezos/v4/forge"
+	"github.com/goat-systems/go-tezos/v4/internal/testutils"
+	"github.com/goat-systems/go-tezos/v4/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Split(t *testing.T) {
+	transaction := func(gas int) rpc.Content {
+		return rpc.Content{
+			Kind:         rpc.TRANSACTION,
+			Source:       "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc",
+			Fee:          rpc.NewMutez(1186),
+			GasLimit:     strconv.Itoa(gas),
+			StorageLimit: "257",
+			Amount:       rpc.NewMutez(1000000),
+			Destination:  mockEmptyAccount,
+		}
+	}
+
+	reveal := rpc.Content{
+		Kind:         rpc.REVEAL,
+		Source:       "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc",
+		Fee:          rpc.NewMutez(367),
+		GasLimit:     "1100",
+		StorageLimit: "0",
+		PublicKey:    "edpkvGfYw3LyB1UcCahKQk4rF2tvbMUk8GFiTuMjL75uGXrpvKXhjn",
+	}
+
+	constants := rpc.Constants{
+		MaxOperationDataLength:       16384,
+		HardGasLimitPerOperation:     1040000,
+		HardGasLimitPerBlock:         10400000,
+		HardStorageLimitPerOperation: 60000,
+	}
+
+	gasOf := func(groups []rpc.Contents) []int {
+		var gas []int
+		for _, group := range groups {
+			var total int
+			for _, content := range group {
+				g, _ := strconv.Atoi(content.GasLimit)
+				total += g
+			}
+			gas = append(gas, total)
+		}
+		return gas
+	}
+
+	t.Run("keeps small contents in one group", func(t *testing.T) {
+		groups, err := Split(constants, 10, reveal, transaction(10307), transaction(10307))
+		testutils.CheckErr(t, false, "", err)
+		assert.Len(t, groups, 1)
+		assert.Len(t, groups[0], 3)
+		assert.Equal(t, rpc.REVEAL, groups[0][0].Kind)
+		assert.Equal(t, "11", groups[0][0].Counter)
+		assert.Equal(t, "13", groups[0][2].Counter)
+	})
+
+	t.Run("splits by gas", func(t *testing.T) {
+		c := constants
+		c.HardGasLimitPerBlock = 2000000
+
+		groups, err := Split(c, 10, transaction(600000), transaction(1000000), transaction(900000), transaction(400000), transaction(1000000))
+		testutils.CheckErr(t, false, "", err)
+		assert.Equal(t, []int{2000000, 1900000}, gasOf(groups))
+
+		// the order is kept within a group
+		assert.Equal(t, "1000000", groups[0][0].GasLimit)
+		assert.Equal(t, "1000000", groups[0][1].GasLimit)
+		assert.Equal(t, "600000", groups[1][0].GasLimit)
+		assert.Equal(t, "900000", groups[1][1].GasLimit)
+		assert.Equal(t, "400000", groups[1][2].GasLimit)
+	})
+
+	t.Run("splits by size", func(t *testing.T) {
+		contents := make([]rpc.Content, 600)
+		for i := range contents {
+			contents[i] = transaction(10307)
+		}
+
+		groups, err := Split(constants, 10, contents...)
+		testutils.CheckErr(t, false, "", err)
+		assert.Len(t, groups, 3)
+
+		counter := 10
+		for _, group := range groups {
+			for _, content := range group {
+				counter++
+				assert.Equal(t, strconv.Itoa(counter), content.Counter)
+			}
+
+			forged, err := forge.Encode(mockBranch, group...)
+			testutils.CheckErr(t, false, "", err)
+			assert.LessOrEqual(t, len(forged)/2+signatureLength, constants.MaxOperationDataLength)
+		}
+		assert.Equal(t, 610, counter)
+	})
+
+	t.Run("keeps reveal first", func(t *testing.T) {
+		c := constants
+		c.HardGasLimitPerBlock = 1001100
+
+		groups, err := Split(c, 10, reveal, transaction(1000000), transaction(1000000))
+		testutils.CheckErr(t, false, "", err)
+		assert.Equal(t, []int{1001100, 1000000}, gasOf(groups))
+		assert.Equal(t, rpc.REVEAL, groups[0][0].Kind)
+		assert.Equal(t, "11", groups[0][0].Counter)
+		assert.Equal(t, "12", groups[0][1].Counter)
+		assert.Equal(t, "13", groups[1][0].Counter)
+	})
+
+	t.Run("handles content over the block gas limit", func(t *testing.T) {
+		c := constants
+		c.HardGasLimitPerBlock = 500000
+
+		_, err := Split(c, 10, transaction(600000))
+		testutils.CheckErr(t, true, "gas limit 600000 of content 0 exceeds the hard gas limit per block 500000", err)
+	})
+
+	t.Run("handles content over the operation gas limit", func(t *testing.T) {
+		_, err := Split(constants, 10, transaction(1040001))
+		testutils.CheckErr(t, true, "exceeds the hard gas limit per operation", err)
+	})
+
+	t.Run("handles content over the size limit", func(t *testing.T) {
+		c := constants
+		c.MaxOperationDataLength = 128
+
+		_, err := Split(c, 10, transaction(10307))
+		testutils.CheckErr(t, true, "content 0 is too large for an operation", err)
+	})
+}
@@ -161,23 +161,15 @@ RPC:
 type BalanceUpdates struct {
 	Kind     string `json:"kind"`
 	Contract string `json:"contract,omitempty"`
-	Change   string `json:"change"`
+	Change   Mutez  `json:"change"`
 	Category string `json:"category,omitempty"`
 	Delegate string `json:"delegate,omitempty"`
 	Cycle    int    `json:"cycle,omitempty"`
 	Level    int    `json:"level,omitempty"`
 }
 
-// ResultError are errors reported by OperationResults
-type ResultError struct {
-	Kind           string           `json:"kind"`
-	ID             string           `json:"id,omitempty"`
-	With           *json.RawMessage `json:"with,omitempty"`
-	Msg            string           `json:"msg,omitempty"`
-	Location       int              `json:"location,omitempty"`
-	ContractHandle string           `json:"contract_handle,omitempty"`
-	ContractCode   *json.RawMessage `json:"contract_code,omitempty"`
-}
+// ResultError are errors reported by OperationResults, which have the same form as the errors of the RPC
+type ResultError = Error
 
 /*
 OperationResult represents the operation result in a Tezos block
@@ -193,6 +185,7 @@ type OperationResult struct {
 	OriginatedContracts          []string         `json:"originated_contracts"`
 	ConsumedGas                  string           `json:"consumed_gas,omitempty"`
 	StorageSize                  string           `json:"storage_size,omitempty"`
+	PaidStorageSizeDiff          string           `json:"paid_storage_size_diff,omitempty"`
 	AllocatedDestinationContract bool             `json:"allocated_destination_contract,omitempty"`
 	Errors                       []ResultError    `json:"errors,omitempty"`
 }
@@ -204,12 +197,13 @@ RPC:
 	https://tezos.gitlab.io/008/rpc.html#get-block-id
 */
 type Operations struct {
-	Protocol  string   `json:"protocol,omitempty"`
-	ChainID   string   `json:"chain_id,omitempty"`
-	Hash      string   `json:"hash,omitempty"`
-	Branch    string   `json:"branch"`
-	Contents  Contents `json:"contents"`
-	Signature string   `json:"signature,omitempty"`
+	Protocol  string        `json:"protocol,omitempty"`
+	ChainID   string        `json:"chain_id,omitempty"`
+	Hash      string        `json:"hash,omitempty"`
+	Branch    string        `json:"branch"`
+	Contents  Contents      `json:"contents"`
+	Signature string        `json:"signature,omitempty"`
+	Error     []ResultError `json:"error,omitempty"`
 }
 
 /*
@@ -305,15 +299,15 @@ type Content struct {
 	Proposals     []string            `json:"proposals,omitempty"`
 	Proposal      string              `json:"proposal,omitempty"`
 	Ballot        string              `json:"ballot,omitempty"`
-	Fee           string              `json:"fee,omitempty"`
+	Fee           Mutez               `json:"fee,omitempty"`
 	Counter       string              `json:"counter,omitempty"`
 	GasLimit      string              `json:"gas_limit,omitempty"`
 	StorageLimit  string              `json:"storage_limit,omitempty"`
 	PublicKey     string              `json:"public_key,omitempty"`
 	ManagerPubkey string              `json:"managerPubKey,omitempty"`
-	Amount        string              `json:"amount,omitempty"`
+	Amount        Mutez               `json:"amount,omitempty"`
 	Destination   string              `json:"destination,omitempty"`
-	Balance       string              `json:"balance,omitempty"`
+	Balance       Mutez               `json:"balance,omitempty"`
 	Delegate      string              `json:"delegate,omitempty"`
 	Script        Script              `json:"script,omitempty"`
 	Parameters    *Parameters         `json:"parameters,omitempty"`
@@ -1072,7 +1066,7 @@ RPC:
 type Reveal struct {
 	Kind         Kind            `json:"kind"`
 	Source       string          `json:"source" validate:"required"`
-	Fee          string          `json:"fee" validate:"required"`
+	Fee          Mutez           `json:"fee" validate:"required"`
 	Counter      string          `json:"counter" validate:"required"`
 	GasLimit     string          `json:"gas_limit" validate:"required"`
 	StorageLimit string          `json:"storage_limit"`
@@ -1129,11 +1123,11 @@ RPC:
 type Transaction struct {
 	Kind         Kind                 `json:"kind"`
 	Source       string               `json:"source" validate:"required"`
-	Fee          string               `json:"fee" validate:"required"`
+	Fee          Mutez                `json:"fee" validate:"required"`
 	Counter      string               `json:"counter" validate:"required"`
 	GasLimit     string               `json:"gas_limit" validate:"required"`
 	StorageLimit string               `json:"storage_limit"`
-	Amount       string               `json:"amount"`
+	Amount       Mutez                `json:"amount"`
 	Destination  string               `json:"destination" validate:"required"`
 	Parameters   *Parameters          `json:"parameters,omitempty"`
 	Metadata     *TransactionMetadata `json:"metadata,omitempty"`
@@ -1207,11 +1201,11 @@ RPC:
 type Origination struct {
 	Kind          Kind                 `json:"kind"`
 	Source        string               `json:"source" validate:"required"`
-	Fee           string               `json:"fee" validate:"required"`
+	Fee           Mutez                `json:"fee" validate:"required"`
 	Counter       string               `json:"counter" validate:"required"`
 	GasLimit      string               `json:"gas_limit" validate:"required"`
 	StorageLimit  string               `json:"storage_limit" validate:"required"`
-	Balance       string               `json:"balance"`
+	Balance       Mutez                `json:"balance"`
 	Delegate      string               `json:"delegate,omitempty"`
 	Script        Script               `json:"script" validate:"required"`
 	ManagerPubkey string               `json:"managerPubkey,omitempty"`
@@ -1286,7 +1280,7 @@ RPC:
 type Delegation struct {
 	Kind         Kind                `json:"kind"`
 	Source       string              `json:"source" validate:"required"`
-	Fee          string              `json:"fee" validate:"required"`
+	Fee          Mutez               `json:"fee" validate:"required"`
 	Counter      string              `json:"counter" validate:"required"`
 	GasLimit     string              `json:"gas_limit" validate:"required"`
 	StorageLimit string              `json:"storage_limit" validate:"required"`
@@ -1343,10 +1337,10 @@ type InternalOperationResults struct {
 	Kind        string            `json:"kind"`
 	Source      string            `json:"source"`
 	Nonce       int               `json:"nonce"`
-	Amount      string            `json:"amount,omitempty"`
+	Amount      Mutez             `json:"amount,omitempty"`
 	PublicKey   string            `json:"public_key,omitempty"`
 	Destination string            `json:"destination,omitempty"`
-	Balance     string            `json:"balance,omitempty"`
+	Balance     Mutez             `json:"balance,omitempty"`
 	Delegate    string            `json:"delegate,omitempty"`
 	Script      ScriptedContracts `json:"script,omitempty"`
 	Parameters  struct {
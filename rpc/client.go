@@ -1,10 +1,10 @@
 package rpc
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"regexp"
 	"strings"
+	"sync"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/pkg/errors"
@@ -13,38 +13,21 @@ import (
 // MUTEZ is mutez on the tezos network
 const MUTEZ = 1000000
 
-var (
-	regRPCError = regexp.MustCompile(`\s?\{\s?\"(kind|error)\"\s?:\s?\"[^,]+\"\s?,\s?\"(kind|error)"\s?:\s?\"[^}]+\s?\}\s?`)
-)
-
 /*
 Client contains a client (http.Client), network contents, and the host of the node. Gives access to
 RPC related functions.
 */
 type Client struct {
 	client           *resty.Client
+	ctx              context.Context
 	chain            string
 	networkConstants *Constants
-	host             string
-}
-
-/*
-Error represents and RPC error
-*/
-type Error struct {
-	Kind string `json:"kind"`
-	Err  string `json:"error"`
+	hosts            *hostPool
+	injectionHosts   *hostPool
+	retryPolicy      RetryPolicy
+	mu               sync.Mutex // guards injectionHosts, which may be set while requests and health checks run
 }
 
-func (r *Error) Error() string {
-	return fmt.Sprintf("rpc error (%s): %s", r.Kind, r.Err)
-}
-
-/*
-Errors represents multiple RPCError(s).s
-*/
-type Errors []Error
-
 type rpcOptions struct {
 	Key   string
 	Value string
@@ -62,12 +45,36 @@ func queryParams(options ...rpcOptions) map[string]string {
 New returns a pointer to a Client and initializes the rpc configuration with the host's Tezos netowrk constants.
 */
 func New(host string) (*Client, error) {
+	return NewWithHosts(host)
+}
+
+/*
+NewWithHosts returns a pointer to a Client that fails over between several nodes, and initializes the rpc
+configuration with the network constants of the first node that answers. Requests go to the first healthy host in
+the order given. A host is considered unhealthy for a while after a request to it fails with a connection error
+or a 429, 502, 503 or 504 status, see SetRetryPolicy to retry such requests on the next host.
+
+Example:
+	client, err := rpc.NewWithHosts("https://node1.example.com", "https://node2.example.com")
+	if err != nil {
+		return err
+	}
+	client.SetRetryPolicy(rpc.DefaultRetryPolicy)
+*/
+func NewWithHosts(hosts ...string) (*Client, error) {
+	if len(hosts) == 0 {
+		return nil, errors.New("failed to initialize library: no hosts")
+	}
+
 	c := &Client{
 		client: resty.New(),
-		host:   cleanseHost(host),
+		hosts:  newHostPool(hosts...),
 		chain:  "main",
 	}
 
+	// failing over to every host is what a pool is for, even without a retry policy
+	c.retryPolicy.MaxRetries = len(hosts) - 1
+
 	_, constants, err := c.Constants(ConstantsInput{BlockID: &BlockIDHead{}})
 	if err != nil {
 		return c, errors.Wrap(err, "failed to initialize library with network constants")
@@ -87,6 +94,41 @@ func (c *Client) CurrentContstants() Constants {
 	return *c.networkConstants
 }
 
+/*
+WithContext returns a shallow copy of the client that carries ctx into every RPC it makes, so deadlines and
+cancellation reach the underlying HTTP requests. The provided ctx must be non-nil.
+
+Example:
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, block, err := client.WithContext(ctx).Block(&rpc.BlockIDHead{})
+*/
+func (c *Client) WithContext(ctx context.Context) IFace {
+	if ctx == nil {
+		panic("nil context")
+	}
+
+	return &Client{
+		client:           c.client,
+		ctx:              ctx,
+		chain:            c.chain,
+		networkConstants: c.networkConstants,
+		hosts:            c.hosts,
+		injectionHosts:   c.injectionPool(),
+		retryPolicy:      c.retryPolicy,
+	}
+}
+
+// Context returns the client's context, which defaults to context.Background
+func (c *Client) Context() context.Context {
+	if c.ctx != nil {
+		return c.ctx
+	}
+
+	return context.Background()
+}
+
 /*
 OverrideClient overrides underlying network client.
 Can allow you to create middleware as needed: https://github.com/go-resty/resty#request-and-response-middleware
@@ -103,42 +145,11 @@ func (c *Client) SetConstants(constants Constants) {
 }
 
 func (c *Client) post(path string, body interface{}, opts ...rpcOptions) (*resty.Response, error) {
-	resp, err := c.client.R().
-		SetQueryParams(queryParams(opts...)).
-		SetHeader("Content-Type", "application/json").
-		SetBody(body).
-		Post(fmt.Sprintf("%s%s", c.host, path))
-
-	if err != nil {
-		return resp, err
-	}
-
-	err = handleRPCError(resp.Body())
-
-	return resp, err
+	return c.do(resty.MethodPost, path, body, opts...)
 }
 
 func (c *Client) get(path string, opts ...rpcOptions) (*resty.Response, error) {
-	resp, err := c.client.R().SetQueryParams(queryParams(opts...)).Get(fmt.Sprintf("%s%s", c.host, path))
-	if err != nil {
-		return resp, err
-	}
-	err = handleRPCError(resp.Body())
-
-	return resp, err
-}
-
-func handleRPCError(resp []byte) error {
-	if regRPCError.Match(resp) {
-		rpcErrors := Errors{}
-		err := json.Unmarshal(resp, &rpcErrors)
-		if err != nil {
-			return nil
-		}
-		return &rpcErrors[0]
-	}
-
-	return nil
+	return c.do(resty.MethodGet, path, nil, opts...)
 }
 
 func cleanseHost(host string) string {
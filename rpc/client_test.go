@@ -1,9 +1,13 @@
 package rpc_test
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/goat-systems/go-tezos/v4/rpc"
 	"github.com/stretchr/testify/assert"
@@ -54,10 +58,279 @@ func Test_SetConstants(t *testing.T) {
 	assert.Equal(t, constants, r.CurrentContstants())
 }
 
+func Test_WithContext(t *testing.T) {
+	slowHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	})
+
+	server := httptest.NewServer(gtGoldenHTTPMock(slowHandler))
+	defer server.Close()
+
+	r, err := rpc.New(server.URL)
+	checkErr(t, false, "", err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	c := r.WithContext(ctx)
+	assert.Equal(t, ctx, c.Context())
+	assert.Equal(t, context.Background(), r.Context())
+
+	start := time.Now()
+	_, _, err = c.Connections()
+	checkErr(t, true, "context deadline exceeded", err)
+	assert.True(t, time.Since(start) < 5*time.Second)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err = r.WithContext(ctx).Block(&rpc.BlockIDHead{})
+	checkErr(t, true, "context canceled", err)
+}
+
 func gtGoldenHTTPMock(next http.Handler) http.Handler {
 	var constantsMock constantsHandlerMock
-	return constantsMock.handler(
+	return headBlockHandlerMock(constantsMock.handler(
 		readResponse(constants),
 		next,
-	)
+	))
+}
+
+func Test_SetRetryPolicy(t *testing.T) {
+	type input struct {
+		failures int
+		policy   rpc.RetryPolicy
+		inject   bool
+	}
+
+	type want struct {
+		err         bool
+		errContains string
+		attempts    int
+	}
+
+	policy := rpc.RetryPolicy{MaxRetries: 3, InitialBackoff: time.Millisecond, MaxBackoff: 4 * time.Millisecond}
+	postPolicy := policy
+	postPolicy.RetryPOST = true
+
+	cases := []struct {
+		name  string
+		input input
+		want  want
+	}{
+		{
+			"is successful after retries",
+			input{2, policy, false},
+			want{false, "", 3},
+		},
+		{
+			"fails without retry policy",
+			input{1, rpc.RetryPolicy{}, false},
+			want{true, "unexpected status '502 Bad Gateway'", 1},
+		},
+		{
+			"fails after max retries",
+			input{10, policy, false},
+			want{true, "unexpected status '502 Bad Gateway'", 4},
+		},
+		{
+			"does not retry POST by default",
+			input{1, policy, true},
+			want{true, "unexpected status '502 Bad Gateway'", 1},
+		},
+		{
+			"is successful retrying POST",
+			input{1, postPolicy, true},
+			want{false, "", 2},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			path := regConnections
+			if tt.input.inject {
+				path = regInjectionOperation
+			}
+
+			failing := &failingHandlerMock{path: path, failures: tt.input.failures}
+			server := httptest.NewServer(gtGoldenHTTPMock(failing.handler(
+				mockHandler(&requestResultPair{regConnections, []byte(`[]`)},
+					mockHandler(&requestResultPair{regInjectionOperation, []byte(`"oopfasdfadjkfalksj"`)}, blankHandler)),
+			)))
+			defer server.Close()
+
+			r, err := rpc.New(server.URL)
+			checkErr(t, false, "", err)
+			r.SetRetryPolicy(tt.input.policy)
+
+			if tt.input.inject {
+				_, _, err = r.InjectionOperation(rpc.InjectionOperationInput{Operation: "some_operation"})
+			} else {
+				_, _, err = r.Connections()
+			}
+			checkErr(t, tt.want.err, tt.want.errContains, err)
+			assert.Equal(t, tt.want.attempts, failing.attempts)
+		})
+	}
+}
+
+func Test_NewWithHosts(t *testing.T) {
+	down := httptest.NewServer(blankHandler)
+	down.Close()
+
+	t.Run("fails over to the next host", func(t *testing.T) {
+		var connections int
+		server := httptest.NewServer(gtGoldenHTTPMock(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if regConnections.MatchString(r.URL.String()) {
+				connections++
+				w.Write([]byte(`[]`))
+			}
+		})))
+		defer server.Close()
+
+		r, err := rpc.NewWithHosts(down.URL, server.URL)
+		checkErr(t, false, "", err)
+
+		_, _, err = r.Connections()
+		checkErr(t, false, "", err)
+		assert.Equal(t, 1, connections)
+	})
+
+	t.Run("fails if every host is down", func(t *testing.T) {
+		_, err := rpc.NewWithHosts(down.URL, down.URL)
+		checkErr(t, true, "failed to initialize library with network constants", err)
+	})
+
+	t.Run("fails without hosts", func(t *testing.T) {
+		_, err := rpc.NewWithHosts()
+		checkErr(t, true, "no hosts", err)
+	})
+}
+
+func Test_SetInjectionHosts(t *testing.T) {
+	reads := httptest.NewServer(gtGoldenHTTPMock(blankHandler))
+	defer reads.Close()
+
+	injections := httptest.NewServer(mockHandler(&requestResultPair{regInjectionOperation, []byte(`"oopfasdfadjkfalksj"`)}, blankHandler))
+	defer injections.Close()
+
+	r, err := rpc.New(reads.URL)
+	checkErr(t, false, "", err)
+	r.SetInjectionHosts(injections.URL)
+
+	_, hash, err := r.InjectionOperation(rpc.InjectionOperationInput{Operation: "some_operation"})
+	checkErr(t, false, "", err)
+	assert.Equal(t, "oopfasdfadjkfalksj", hash)
+
+	r.SetInjectionHosts()
+	_, _, err = r.InjectionOperation(rpc.InjectionOperationInput{Operation: "some_operation"})
+	checkErr(t, true, "failed to parse json", err)
+}
+
+func Test_SetInjectionHosts_WhileRunning(t *testing.T) {
+	reads := httptest.NewServer(gtGoldenHTTPMock(blankHandler))
+	defer reads.Close()
+
+	injections := httptest.NewServer(gtGoldenHTTPMock(mockHandler(&requestResultPair{regInjectionOperation, []byte(`"oopfasdfadjkfalksj"`)}, blankHandler)))
+	defer injections.Close()
+
+	r, err := rpc.New(reads.URL)
+	checkErr(t, false, "", err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r.StartHealthCheck(ctx, time.Millisecond)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 20; i++ {
+			r.SetInjectionHosts(injections.URL)
+			r.WithContext(ctx)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 20; i++ {
+			r.InjectionOperation(rpc.InjectionOperationInput{Operation: "some_operation"})
+		}
+	}()
+	wg.Wait()
+
+	_, hash, err := r.InjectionOperation(rpc.InjectionOperationInput{Operation: "some_operation"})
+	checkErr(t, false, "", err)
+	assert.Equal(t, "oopfasdfadjkfalksj", hash)
+}
+
+func Test_StartHealthCheck(t *testing.T) {
+	var unhealthyConnections, healthyConnections int
+	unhealthy := httptest.NewServer(gtGoldenHTTPMock(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/chains/main/blocks/head/hash" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		unhealthyConnections++
+		w.Write([]byte(`[]`))
+	})))
+	defer unhealthy.Close()
+
+	var checked = make(chan struct{}, 1)
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/chains/main/blocks/head/hash" {
+			w.Write([]byte(`"BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1"`))
+			select {
+			case checked <- struct{}{}:
+			default:
+			}
+			return
+		}
+
+		healthyConnections++
+		w.Write([]byte(`[]`))
+	}))
+	defer healthy.Close()
+
+	r, err := rpc.NewWithHosts(unhealthy.URL, healthy.URL)
+	checkErr(t, false, "", err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r.StartHealthCheck(ctx, time.Hour)
+
+	select {
+	case <-checked:
+	case <-time.After(5 * time.Second):
+		t.Fatal("health check did not run")
+	}
+
+	_, _, err = r.Connections()
+	checkErr(t, false, "", err)
+	assert.Equal(t, 0, unhealthyConnections)
+	assert.Equal(t, 1, healthyConnections)
+}
+
+// failingHandlerMock fails the first requests to path with a 502.
+type failingHandlerMock struct {
+	path     *regexp.Regexp
+	failures int
+	attempts int
+}
+
+func (f *failingHandlerMock) handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if f.path.MatchString(r.URL.String()) {
+			f.attempts++
+			if f.attempts <= f.failures {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
 }
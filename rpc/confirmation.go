@@ -0,0 +1,237 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrOperationExpired is returned by WaitForConfirmation when an operation can no longer be included because
+	// its branch fell out of the last max_operations_ttl blocks.
+	ErrOperationExpired = errors.New("operation expired")
+	// ErrOperationDropped is returned by WaitForConfirmation when the block an operation was included in is
+	// replaced by a reorg before the operation reached the requested number of confirmations, and the operation
+	// is not included again before it expires.
+	ErrOperationDropped = errors.New("operation dropped by reorg")
+)
+
+/*
+WaitForConfirmationInput is the input for the WaitForConfirmation function.
+
+Function:
+	func (c *Client) WaitForConfirmation(input WaitForConfirmationInput) (Confirmation, error)
+*/
+type WaitForConfirmationInput struct {
+	// The hash of the operation, as returned by InjectionOperation.
+	OperationHash string `validate:"required,operation_hash"`
+	// The number of blocks to wait for on top of the block that includes the operation.
+	Confirmations int `validate:"min=0"`
+	// The branch the operation was forged against. Blocks are searched for the operation from the branch and
+	// expiry is counted from it when it is set. Otherwise the last max_operations_ttl blocks before the call are
+	// searched and expiry is counted from the head at the time of the call.
+	Branch string `validate:"omitempty,block_hash"`
+	// Return ErrOperationDropped as soon as a reorg replaces the block that included the operation, instead of
+	// waiting for the operation to be included again until it expires.
+	FailOnReorg bool
+}
+
+/*
+Confirmation is an operation included in a block that reached the requested number of confirmations.
+*/
+type Confirmation struct {
+	BlockHash     string
+	BlockLevel    int
+	Confirmations int
+	// The included operation. The operation results are found in the metadata of its contents.
+	Operation Operations
+}
+
+/*
+WaitForConfirmation blocks until the operation is included in a block with at least input.Confirmations blocks
+on top of it, and returns the included operation with its metadata.
+
+Every new head is checked against the blocks seen before it, so a reorg is noticed even when it keeps the head
+level. If the block that included the operation is replaced, the operation goes back to the mempool and the wait
+goes on until a later block includes it again. Once the head is more than max_operations_ttl blocks past the
+operation's branch, ErrOperationDropped is returned if the operation was included and dropped by a reorg, and
+ErrOperationExpired if it was never included. Set input.FailOnReorg to return ErrOperationDropped as soon as the
+including block is replaced. Use WithContext to bound the wait.
+
+Passing the branch the operation was forged against is recommended: without it, an operation included before the
+call can only be found by searching the last max_operations_ttl blocks, which costs two RPCs per block on the
+first check.
+
+Example:
+	_, hash, err := client.InjectionOperation(rpc.InjectionOperationInput{Operation: signedOp})
+	...
+	confirmation, err := client.WaitForConfirmation(rpc.WaitForConfirmationInput{
+		OperationHash: hash,
+		Confirmations: 2,
+		Branch:        branch,
+	})
+*/
+func (c *Client) WaitForConfirmation(input WaitForConfirmationInput) (Confirmation, error) {
+	err := validateInput(input)
+	if err != nil {
+		return Confirmation{}, errors.Wrap(err, "invalid input")
+	}
+
+	ctx, cancel := context.WithCancel(c.Context())
+	defer cancel()
+	client := c.WithContext(ctx)
+
+	_, head, err := client.Block(&BlockIDHead{})
+	if err != nil {
+		return Confirmation{}, errors.Wrapf(err, "failed to wait for operation '%s'", input.OperationHash)
+	}
+
+	w := &confirmationWaiter{
+		client:   client,
+		input:    input,
+		scanFrom: head.Header.Level - head.Metadata.MaxOperationsTTL + 1,
+		expiry:   head.Header.Level + head.Metadata.MaxOperationsTTL,
+		scanned:  map[int]string{},
+	}
+	if w.scanFrom < 0 {
+		w.scanFrom = 0
+	}
+
+	if input.Branch != "" {
+		id := BlockIDHash(input.Branch)
+		_, branch, err := client.Header(&id)
+		if err != nil {
+			return Confirmation{}, errors.Wrapf(err, "failed to wait for operation '%s'", input.OperationHash)
+		}
+
+		w.scanFrom = branch.Level + 1
+		w.expiry = branch.Level + head.Metadata.MaxOperationsTTL
+	}
+
+	heads, errs := client.MonitorHeads(MonitorHeadsInput{})
+
+	next := MonitoredBlock{Hash: head.Hash, Header: head.Header}
+	for {
+		confirmation, done, err := w.process(next)
+		if err != nil {
+			return Confirmation{}, errors.Wrapf(err, "failed to wait for operation '%s'", input.OperationHash)
+		}
+		if done {
+			return confirmation, nil
+		}
+
+		var ok bool
+		for received := false; !received; {
+			select {
+			case next, ok = <-heads:
+				if !ok {
+					return Confirmation{}, errors.Wrapf(ctx.Err(), "failed to wait for operation '%s'", input.OperationHash)
+				}
+				received = true
+			case <-errs:
+				// The head stream reconnects on its own.
+			}
+		}
+	}
+}
+
+type confirmationWaiter struct {
+	client   IFace
+	input    WaitForConfirmationInput
+	scanFrom int
+	expiry   int
+	// scanned maps every level checked so far to the hash of the block seen at that level.
+	scanned       map[int]string
+	includedLevel int
+	includedHash  string
+	// dropped is set once a reorg replaces a block that included the operation.
+	dropped bool
+}
+
+/*
+process walks back from head until it reaches a block that was already scanned, checking the operation hashes
+of every new block on the way. The walk stops early at the first level scanned before, so a single new head costs
+one lookup while a reorg rescans every replaced block.
+*/
+func (w *confirmationWaiter) process(head MonitoredBlock) (Confirmation, bool, error) {
+	for level := head.Level; level >= w.scanFrom; level-- {
+		id := BlockIDPredecessor{Hash: head.Hash, DiffLevel: head.Level - level}
+
+		hash := head.Hash
+		if level != head.Level {
+			var err error
+			if _, hash, err = w.client.Hash(&id); err != nil {
+				return Confirmation{}, false, err
+			}
+		}
+
+		if w.scanned[level] == hash {
+			break
+		}
+
+		if level == w.includedLevel && hash != w.includedHash {
+			w.includedLevel, w.includedHash = 0, ""
+			w.dropped = true
+		}
+
+		_, hashes, err := w.client.OperationHashes(OperationHashesInput{BlockID: &id})
+		if err != nil {
+			return Confirmation{}, false, err
+		}
+		w.scanned[level] = hash
+
+		for _, h := range hashes {
+			if h == w.input.OperationHash {
+				w.includedLevel, w.includedHash = level, hash
+			}
+		}
+	}
+
+	for level := range w.scanned {
+		if level > head.Level {
+			delete(w.scanned, level)
+			if level == w.includedLevel {
+				w.includedLevel, w.includedHash = 0, ""
+				w.dropped = true
+			}
+		}
+	}
+
+	if w.includedHash == "" {
+		if w.dropped && (w.input.FailOnReorg || head.Level > w.expiry) {
+			return Confirmation{}, false, ErrOperationDropped
+		}
+
+		if head.Level > w.expiry {
+			return Confirmation{}, false, ErrOperationExpired
+		}
+
+		return Confirmation{}, false, nil
+	}
+
+	confirmations := head.Level - w.includedLevel
+	if confirmations < w.input.Confirmations {
+		return Confirmation{}, false, nil
+	}
+
+	id := BlockIDHash(w.includedHash)
+	_, block, err := w.client.Block(&id)
+	if err != nil {
+		return Confirmation{}, false, err
+	}
+
+	for _, pass := range block.Operations {
+		for _, operation := range pass {
+			if operation.Hash == w.input.OperationHash {
+				return Confirmation{
+					BlockHash:     w.includedHash,
+					BlockLevel:    w.includedLevel,
+					Confirmations: confirmations,
+					Operation:     operation,
+				}, true, nil
+			}
+		}
+	}
+
+	return Confirmation{}, false, errors.Errorf("operation missing from block '%s'", w.includedHash)
+}
@@ -0,0 +1,252 @@
+package rpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goat-systems/go-tezos/v4/rpc"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockChainBlock struct {
+	hash        string
+	level       int
+	predecessor string
+	operations  []string
+}
+
+// chainHandlerMock simulates a chain of blocks. The head RPCs resolve to head and the heads stream announces
+// heads in order, so forks can be simulated by announcing blocks on different branches.
+func chainHandlerMock(blocks []mockChainBlock, head string, heads []string, ttl int) http.Handler {
+	byHash := map[string]mockChainBlock{}
+	for _, b := range blocks {
+		byHash[b.hash] = b
+	}
+
+	resolve := func(id string) (mockChainBlock, bool) {
+		if id == "head" {
+			id = head
+		}
+
+		parts := strings.Split(id, "~")
+		b, ok := byHash[parts[0]]
+		if len(parts) == 2 {
+			diff, _ := strconv.Atoi(parts[1])
+			for ; ok && diff > 0; diff-- {
+				b, ok = byHash[b.predecessor]
+			}
+		}
+		return b, ok
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/monitor/heads/main" {
+			for _, h := range heads {
+				b := byHash[h]
+				fmt.Fprintf(w, `{"hash":"%s","level":%d,"predecessor":"%s"}`+"\n", b.hash, b.level, b.predecessor)
+				w.(http.Flusher).Flush()
+			}
+			<-r.Context().Done()
+			return
+		}
+
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/chains/main/blocks/"), "/")
+		b, ok := resolve(parts[0])
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		switch {
+		case len(parts) == 1:
+			var operations []rpc.Operations
+			for _, op := range b.operations {
+				operations = append(operations, rpc.Operations{
+					Hash:   op,
+					Branch: b.predecessor,
+					Contents: rpc.Contents{
+						{
+							Kind: rpc.TRANSACTION,
+							Metadata: &rpc.ContentsMetadata{
+								OperationResults: &rpc.OperationResults{Status: "applied"},
+							},
+						},
+					},
+				})
+			}
+
+			v, _ := json.Marshal(rpc.Block{
+				Hash:       b.hash,
+				Header:     rpc.Header{Level: b.level, Predecessor: b.predecessor},
+				Metadata:   rpc.Metadata{MaxOperationsTTL: ttl},
+				Operations: [][]rpc.Operations{{}, {}, {}, operations},
+			})
+			w.Write(v)
+		case parts[1] == "hash":
+			fmt.Fprintf(w, `"%s"`, b.hash)
+		case parts[1] == "header":
+			fmt.Fprintf(w, `{"level":%d,"predecessor":"%s"}`, b.level, b.predecessor)
+		case parts[1] == "operation_hashes":
+			v, _ := json.Marshal([][]string{{}, {}, {}, b.operations})
+			w.Write(v)
+		}
+	})
+}
+
+func Test_WaitForConfirmation(t *testing.T) {
+	opHash := "oozWCsudcyv9vdp8xzpBNLeaogU6fNHg4ikKYonJYhJiC7jw1W7"
+	// the branch is validated, so the root of the mock chain has a real block hash
+	b0 := "BLQMkH2PSTuAJgVm6rGHshY5z6Z6SAmqXv6q1LDzhX6fchJ12Up"
+
+	chain := []mockChainBlock{
+		{hash: "B-1", level: 99},
+		{hash: b0, level: 100, predecessor: "B-1"},
+		{hash: "B1", level: 101, predecessor: b0, operations: []string{opHash}},
+		{hash: "B2", level: 102, predecessor: "B1"},
+		{hash: "B3", level: 103, predecessor: "B2"},
+		{hash: "F1", level: 101, predecessor: b0},
+		{hash: "F2", level: 102, predecessor: "F1", operations: []string{opHash}},
+		{hash: "F3", level: 103, predecessor: "F2"},
+		{hash: "E1", level: 101, predecessor: b0},
+		{hash: "E2", level: 102, predecessor: "E1"},
+		{hash: "E3", level: 103, predecessor: "E2"},
+		{hash: "G1", level: 101, predecessor: b0},
+		{hash: "G2", level: 102, predecessor: "G1", operations: []string{opHash}},
+		{hash: "G3", level: 103, predecessor: "G2"},
+	}
+
+	type want struct {
+		err          bool
+		errContains  string
+		cause        error
+		confirmation rpc.Confirmation
+	}
+
+	cases := []struct {
+		name  string
+		input rpc.WaitForConfirmationInput
+		head  string
+		heads []string
+		want
+	}{
+		{
+			"handles invalid input",
+			rpc.WaitForConfirmationInput{},
+			b0,
+			nil,
+			want{true, "invalid input", nil, rpc.Confirmation{}},
+		},
+		{
+			"handles invalid operation hash",
+			rpc.WaitForConfirmationInput{OperationHash: "oozWCsudcyv9vdp8xzpBNLeaogU6fNHg4ikKYonJYhJiC7jw1W8"},
+			b0,
+			nil,
+			want{true, "invalid input: invalid OperationHash: invalid operation hash 'oozWCsudcyv9vdp8xzpBNLeaogU6fNHg4ikKYonJYhJiC7jw1W8': data and checksum don't match", nil, rpc.Confirmation{}},
+		},
+		{
+			"is successful with confirmations",
+			rpc.WaitForConfirmationInput{OperationHash: opHash, Confirmations: 2},
+			b0,
+			[]string{"B1", "B2", "B3"},
+			want{false, "", nil, rpc.Confirmation{BlockHash: "B1", BlockLevel: 101, Confirmations: 2}},
+		},
+		{
+			"is successful if already included",
+			rpc.WaitForConfirmationInput{OperationHash: opHash, Branch: b0},
+			"B2",
+			nil,
+			want{false, "", nil, rpc.Confirmation{BlockHash: "B1", BlockLevel: 101, Confirmations: 1}},
+		},
+		{
+			"is successful if included before the call without branch",
+			rpc.WaitForConfirmationInput{OperationHash: opHash},
+			"B2",
+			nil,
+			want{false, "", nil, rpc.Confirmation{BlockHash: "B1", BlockLevel: 101, Confirmations: 1}},
+		},
+		{
+			"is successful if a reorg includes it again",
+			rpc.WaitForConfirmationInput{OperationHash: opHash, Confirmations: 1},
+			b0,
+			[]string{"B1", "F2", "F3"},
+			want{false, "", nil, rpc.Confirmation{BlockHash: "F2", BlockLevel: 102, Confirmations: 1}},
+		},
+		{
+			"is successful if a later block includes it again after a reorg",
+			rpc.WaitForConfirmationInput{OperationHash: opHash, Confirmations: 1},
+			b0,
+			[]string{"B1", "G1", "G2", "G3"},
+			want{false, "", nil, rpc.Confirmation{BlockHash: "G2", BlockLevel: 102, Confirmations: 1}},
+		},
+		{
+			"handles reorg that drops the operation until it expires",
+			rpc.WaitForConfirmationInput{OperationHash: opHash, Confirmations: 2},
+			b0,
+			[]string{"B1", "E1", "E2", "E3"},
+			want{true, "failed to wait for operation", rpc.ErrOperationDropped, rpc.Confirmation{}},
+		},
+		{
+			"handles reorg that drops the operation with fail on reorg",
+			rpc.WaitForConfirmationInput{OperationHash: opHash, Confirmations: 2, FailOnReorg: true},
+			b0,
+			[]string{"B1", "E1"},
+			want{true, "failed to wait for operation", rpc.ErrOperationDropped, rpc.Confirmation{}},
+		},
+		{
+			"handles expired operation",
+			rpc.WaitForConfirmationInput{OperationHash: opHash, Branch: b0},
+			b0,
+			[]string{"E1", "E2", "E3"},
+			want{true, "operation expired", rpc.ErrOperationExpired, rpc.Confirmation{}},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(gtGoldenHTTPMock(chainHandlerMock(chain, tt.head, tt.heads, 2)))
+			defer server.Close()
+
+			r, err := rpc.New(server.URL)
+			checkErr(t, false, "", err)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			confirmation, err := r.WithContext(ctx).WaitForConfirmation(tt.input)
+			checkErr(t, tt.want.err, tt.want.errContains, err)
+			if tt.want.cause != nil {
+				assert.Equal(t, tt.want.cause, errors.Cause(err))
+			}
+
+			assert.Equal(t, tt.want.confirmation.BlockHash, confirmation.BlockHash)
+			assert.Equal(t, tt.want.confirmation.BlockLevel, confirmation.BlockLevel)
+			assert.Equal(t, tt.want.confirmation.Confirmations, confirmation.Confirmations)
+			if !tt.want.err {
+				assert.Equal(t, opHash, confirmation.Operation.Hash)
+				assert.Equal(t, "applied", confirmation.Operation.Contents[0].Metadata.OperationResults.Status)
+			}
+		})
+	}
+}
+
+func Test_WaitForConfirmation_Cancel(t *testing.T) {
+	server := httptest.NewServer(gtGoldenHTTPMock(chainHandlerMock([]mockChainBlock{{hash: "B0", level: 100}}, "B0", nil, 1)))
+	defer server.Close()
+
+	r, err := rpc.New(server.URL)
+	checkErr(t, false, "", err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	_, err = r.WithContext(ctx).WaitForConfirmation(rpc.WaitForConfirmationInput{OperationHash: "oozWCsudcyv9vdp8xzpBNLeaogU6fNHg4ikKYonJYhJiC7jw1W7"})
+	checkErr(t, true, "context deadline exceeded", err)
+}
@@ -6,14 +6,13 @@ import (
 	"strconv"
 	"strings"
 
-	validator "github.com/go-playground/validator/v10"
 	"github.com/go-resty/resty/v2"
 	"github.com/pkg/errors"
 	"github.com/valyala/fastjson"
 )
 
 func (c *Client) processContextRequest(input interface{}, cycle int, blockID BlockID) (*resty.Response, BlockID, error) {
-	err := validator.New().Struct(input)
+	err := validateInput(input)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "invalid input")
 	}
@@ -269,7 +268,7 @@ RPC:
 	https://tezos.gitlab.io/008/rpc.html#get-block-id-context-contracts-contract-id
 */
 type Contract struct {
-	Balance  string `json:"balance"`
+	Balance  Mutez  `json:"balance"`
 	Delegate string `json:"delegate,omitempty"`
 	Script   struct {
 		Code    *json.RawMessage
@@ -290,7 +289,7 @@ type ContractInput struct {
 	// The cycle to get the balance at. If not provided BlockID is required.
 	Cycle int
 	// The contract ID of the contract you wish to get.
-	ContractID string `validate:"required"`
+	ContractID string `validate:"required,address"`
 }
 
 /*
@@ -334,7 +333,7 @@ type ContractBalanceInput struct {
 	// The cycle to get the balance at. If not provided Blockhash is required.
 	Cycle int
 	// The contract ID of the contract balance you wish to get.
-	ContractID string `validate:"required"`
+	ContractID string `validate:"required,address"`
 }
 
 /*
@@ -346,20 +345,20 @@ Path:
 RPC:
 	https://tezos.gitlab.io/008/rpc.html#get-block-id-context-contracts-contract-id-balance
 */
-func (c *Client) ContractBalance(input ContractBalanceInput) (*resty.Response, string, error) {
+func (c *Client) ContractBalance(input ContractBalanceInput) (*resty.Response, Mutez, error) {
 	resp, blockID, err := c.processContextRequest(input, input.Cycle, input.BlockID)
 	if err != nil {
-		return resp, "", errors.Wrap(err, "failed to get balance")
+		return resp, Mutez{}, errors.Wrap(err, "failed to get balance")
 	}
 
 	resp, err = c.get(fmt.Sprintf("/chains/%s/blocks/%s/context/contracts/%s/balance", c.chain, blockID.ID(), input.ContractID))
 	if err != nil {
-		return resp, "", errors.Wrapf(err, "failed to get balance for contract '%s'", input.ContractID)
+		return resp, Mutez{}, errors.Wrapf(err, "failed to get balance for contract '%s'", input.ContractID)
 	}
 
-	var balance string
+	var balance Mutez
 	if err = json.Unmarshal(resp.Body(), &balance); err != nil {
-		return resp, "", errors.Wrapf(err, "failed to get balance for contract '%s': failed to parse json", input.ContractID)
+		return resp, Mutez{}, errors.Wrapf(err, "failed to get balance for contract '%s': failed to parse json", input.ContractID)
 	}
 
 	return resp, balance, nil
@@ -377,7 +376,7 @@ type ContractCounterInput struct {
 	// The cycle to get the balance at. If not provided Blockhash is required.
 	Cycle int
 	// The contract ID of the contract counter you wish to get.
-	ContractID string `validate:"required"`
+	ContractID string `validate:"required,address"`
 }
 
 /*
@@ -425,7 +424,7 @@ type ContractDelegateInput struct {
 	// The cycle to get the balance at. If not provided BlockID is required.
 	Cycle int
 	// The contract ID of the contract delegate you wish to get.
-	ContractID string `validate:"required"`
+	ContractID string `validate:"required,address"`
 }
 
 /*
@@ -467,7 +466,7 @@ type ContractEntrypointsInput struct {
 	// The block of which you want to make the query.
 	BlockID BlockID `validate:"required"`
 	// The contract ID of the contract delegate you wish to get.
-	ContractID string `validate:"required"`
+	ContractID string `validate:"required,address"`
 }
 
 /*
@@ -481,7 +480,7 @@ RPC:
 	https://tezos.gitlab.io/008/rpc.html#get-block-id-context-contracts-contract-id-entrypoints
 */
 func (c *Client) ContractEntrypoints(input ContractEntrypointsInput) (*resty.Response, map[string]*json.RawMessage, error) {
-	err := validator.New().Struct(input)
+	err := validateInput(input)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "failed to get entrypoints: invalid input")
 	}
@@ -528,7 +527,7 @@ type ContractEntrypointInput struct {
 	// The block of which you want to make the query.
 	BlockID BlockID `validate:"required"`
 	// The contract ID of the contract delegate you wish to get.
-	ContractID string `validate:"required"`
+	ContractID string `validate:"required,address"`
 	// The entrypoint of the contract you wish to get.
 	Entrypoint string `validate:"required"`
 }
@@ -543,7 +542,7 @@ RPC:
 	https://tezos.gitlab.io/008/rpc.html#get-block-id-context-contracts-contract-id-entrypoints
 */
 func (c *Client) ContractEntrypoint(input ContractEntrypointInput) (*resty.Response, *json.RawMessage, error) {
-	err := validator.New().Struct(input)
+	err := validateInput(input)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "failed to get entrypoint: invalid input")
 	}
@@ -569,7 +568,7 @@ type ContractManagerKeyInput struct {
 	// The block of which you want to make the query.
 	BlockID BlockID `validate:"required"`
 	// The contract ID of the contract delegate you wish to get.
-	ContractID string `validate:"required"`
+	ContractID string `validate:"required,address"`
 }
 
 /*
@@ -582,7 +581,7 @@ RPC:
 	https://tezos.gitlab.io/008/rpc.html#get-block-id-context-contracts-contract-id-entrypoints
 */
 func (c *Client) ContractManagerKey(input ContractManagerKeyInput) (*resty.Response, string, error) {
-	err := validator.New().Struct(input)
+	err := validateInput(input)
 	if err != nil {
 		return nil, "", errors.Wrap(err, "failed to get manager: invalid input")
 	}
@@ -613,7 +612,7 @@ type ContractScriptInput struct {
 	// The cycle to get the balance at. If not provided BlockID is required.
 	Cycle int
 	// The contract ID of the contract delegate you wish to get.
-	ContractID string `validate:"required"`
+	ContractID string `validate:"required,address"`
 }
 
 /*
@@ -651,7 +650,7 @@ type ContractSaplingDiffInput struct {
 	// The cycle to get the balance at. If not provided BlockID is required.
 	Cycle int
 	// The contract ID of the contract delegate you wish to get.
-	ContractID string `validate:"required"`
+	ContractID string `validate:"required,address"`
 	//  Commitments and ciphertexts are returned from the specified offset up to the most recent.
 	OffsetCommitment int
 	// Nullifiers are returned from the specified offset up to the most recent.
@@ -724,7 +723,7 @@ type ContractStorageInput struct {
 	// The cycle to get the balance at. If not provided BlockID is required.
 	Cycle int
 	// The contract ID of the contract delegate you wish to get.
-	ContractID string `validate:"required"`
+	ContractID string `validate:"required,address"`
 }
 
 /*
@@ -832,17 +831,17 @@ RPC:
 	https://tezos.gitlab.io/008/rpc.html#get-block-id-context-delegates-pkh
 */
 type Delegate struct {
-	Balance              string `json:"balance"`
-	FrozenBalance        string `json:"frozen_balance"`
+	Balance              Mutez `json:"balance"`
+	FrozenBalance        Mutez `json:"frozen_balance"`
 	FrozenBalanceByCycle []struct {
-		Cycle   int `json:"cycle"`
-		Deposit int `json:"deposit,string"`
-		Fees    int `json:"fees,string"`
-		Rewards int `json:"rewards,string"`
+		Cycle   int   `json:"cycle"`
+		Deposit Mutez `json:"deposit"`
+		Fees    Mutez `json:"fees"`
+		Rewards Mutez `json:"rewards"`
 	} `json:"frozen_balance_by_cycle"`
-	StakingBalance    string   `json:"staking_balance"`
+	StakingBalance    Mutez    `json:"staking_balance"`
 	DelegateContracts []string `json:"delegated_contracts"`
-	DelegatedBalance  string   `json:"delegated_balance"`
+	DelegatedBalance  Mutez    `json:"delegated_balance"`
 	Deactivated       bool     `json:"deactivated"`
 	GracePeriod       int      `json:"grace_period"`
 }
@@ -859,7 +858,7 @@ type DelegateInput struct {
 	// The cycle to get the balance at. If not provided BlockID is required.
 	Cycle int
 	// The delegate that you want to make the query.
-	Delegate string `validate:"required"`
+	Delegate string `validate:"required,key_hash"`
 }
 
 /*
@@ -903,7 +902,7 @@ type DelegateBalanceInput struct {
 	// The cycle to get the balance at. If not provided BlockID is required.
 	Cycle int
 	// The delegate that you want to make the query.
-	Delegate string `validate:"required"`
+	Delegate string `validate:"required,key_hash"`
 }
 
 /*
@@ -915,21 +914,21 @@ Path:
 RPC:
 	https://tezos.gitlab.io/008/rpc.html#get-block-id-context-delegates-pkh-balance
 */
-func (c *Client) DelegateBalance(input DelegateBalanceInput) (*resty.Response, string, error) {
+func (c *Client) DelegateBalance(input DelegateBalanceInput) (*resty.Response, Mutez, error) {
 	resp, blockID, err := c.processContextRequest(input, input.Cycle, input.BlockID)
 	if err != nil {
-		return resp, "", errors.Wrap(err, "failed to get delegate balance")
+		return resp, Mutez{}, errors.Wrap(err, "failed to get delegate balance")
 	}
 
 	resp, err = c.get(fmt.Sprintf("/chains/%s/blocks/%s/context/delegates/%s/balance", c.chain, blockID.ID(), input.Delegate))
 	if err != nil {
-		return resp, "", errors.Wrapf(err, "failed to get delegate '%s' balance", input.Delegate)
+		return resp, Mutez{}, errors.Wrapf(err, "failed to get delegate '%s' balance", input.Delegate)
 	}
 
-	var balance string
+	var balance Mutez
 	err = json.Unmarshal(resp.Body(), &balance)
 	if err != nil {
-		return resp, "", errors.Wrapf(err, "failed to get delegate '%s' balance: failed to parse json", input.Delegate)
+		return resp, Mutez{}, errors.Wrapf(err, "failed to get delegate '%s' balance: failed to parse json", input.Delegate)
 	}
 
 	return resp, balance, nil
@@ -947,7 +946,7 @@ type DelegateDeactivatedInput struct {
 	// The cycle to get the balance at. If not provided BlockID is required.
 	Cycle int
 	// The delegate that you want to make the query.
-	Delegate string `validate:"required"`
+	Delegate string `validate:"required,key_hash"`
 }
 
 /*
@@ -991,7 +990,7 @@ type DelegateDelegatedBalanceInput struct {
 	// The cycle to get the balance at. If not provided BlockID is required.
 	Cycle int
 	// The delegate that you want to make the query.
-	Delegate string `validate:"required"`
+	Delegate string `validate:"required,key_hash"`
 }
 
 /*
@@ -1004,21 +1003,21 @@ Path:
 RPC:
 	https://tezos.gitlab.io/008/rpc.html#get-block-id-context-delegates-pkh-delegated-balance
 */
-func (c *Client) DelegateDelegatedBalance(input DelegateDelegatedBalanceInput) (*resty.Response, string, error) {
+func (c *Client) DelegateDelegatedBalance(input DelegateDelegatedBalanceInput) (*resty.Response, Mutez, error) {
 	resp, blockID, err := c.processContextRequest(input, input.Cycle, input.BlockID)
 	if err != nil {
-		return resp, "", errors.Wrap(err, "failed to get delegate delegated balance")
+		return resp, Mutez{}, errors.Wrap(err, "failed to get delegate delegated balance")
 	}
 
 	resp, err = c.get(fmt.Sprintf("/chains/%s/blocks/%s/context/delegates/%s/delegated_balance", c.chain, blockID.ID(), input.Delegate))
 	if err != nil {
-		return resp, "", errors.Wrapf(err, "failed to get delegate '%s' delegated balance", input.Delegate)
+		return resp, Mutez{}, errors.Wrapf(err, "failed to get delegate '%s' delegated balance", input.Delegate)
 	}
 
-	var balance string
+	var balance Mutez
 	err = json.Unmarshal(resp.Body(), &balance)
 	if err != nil {
-		return resp, "", errors.Wrapf(err, "failed to get delegate '%s' delegated balance: failed to parse json", input.Delegate)
+		return resp, Mutez{}, errors.Wrapf(err, "failed to get delegate '%s' delegated balance: failed to parse json", input.Delegate)
 	}
 
 	return resp, balance, nil
@@ -1036,7 +1035,7 @@ type DelegateDelegatedContractsInput struct {
 	// The cycle to get the balance at. If not provided BlockID is required.
 	Cycle int
 	// The delegate that you want to make the query.
-	Delegate string `validate:"required"`
+	Delegate string `validate:"required,key_hash"`
 }
 
 /*
@@ -1080,7 +1079,7 @@ type DelegateFrozenBalanceInput struct {
 	// The cycle to get the balance at. If not provided BlockID is required.
 	Cycle int
 	// The delegate that you want to make the query.
-	Delegate string `validate:"required"`
+	Delegate string `validate:"required,key_hash"`
 }
 
 /*
@@ -1093,21 +1092,21 @@ Path:
 RPC:
 	https://tezos.gitlab.io/008/rpc.html#get-block-id-context-delegates-pkh-frozen-balance
 */
-func (c *Client) DelegateFrozenBalance(input DelegateFrozenBalanceInput) (*resty.Response, string, error) {
+func (c *Client) DelegateFrozenBalance(input DelegateFrozenBalanceInput) (*resty.Response, Mutez, error) {
 	resp, blockID, err := c.processContextRequest(input, input.Cycle, input.BlockID)
 	if err != nil {
-		return resp, "", errors.Wrap(err, "failed to get delegate frozen balance")
+		return resp, Mutez{}, errors.Wrap(err, "failed to get delegate frozen balance")
 	}
 
 	resp, err = c.get(fmt.Sprintf("/chains/%s/blocks/%s/context/delegates/%s/frozen_balance", c.chain, blockID.ID(), input.Delegate))
 	if err != nil {
-		return resp, "", errors.Wrapf(err, "failed to get delegate '%s' frozen balance", input.Delegate)
+		return resp, Mutez{}, errors.Wrapf(err, "failed to get delegate '%s' frozen balance", input.Delegate)
 	}
 
-	var balance string
+	var balance Mutez
 	err = json.Unmarshal(resp.Body(), &balance)
 	if err != nil {
-		return resp, "", errors.Wrapf(err, "failed to get delegate '%s' frozen balance: failed to parse json", input.Delegate)
+		return resp, Mutez{}, errors.Wrapf(err, "failed to get delegate '%s' frozen balance: failed to parse json", input.Delegate)
 	}
 
 	return resp, balance, nil
@@ -1121,9 +1120,9 @@ RPC:
 */
 type FrozenBalanceByCycle struct {
 	Cycle   int
-	Deposit string
-	Fees    string
-	Rewards string
+	Deposit Mutez
+	Fees    Mutez
+	Rewards Mutez
 }
 
 /*
@@ -1138,7 +1137,7 @@ type DelegateFrozenBalanceByCycleInput struct {
 	// The cycle to get the balance at. If not provided BlockID is required.
 	Cycle int
 	// The delegate that you want to make the query.
-	Delegate string `validate:"required"`
+	Delegate string `validate:"required,key_hash"`
 }
 
 /*
@@ -1183,7 +1182,7 @@ type DelegateGracePeriodInput struct {
 	// The cycle to get the balance at. If not provided BlockID is required.
 	Cycle int
 	// The delegate that you want to make the query.
-	Delegate string `validate:"required"`
+	Delegate string `validate:"required,key_hash"`
 }
 
 /*
@@ -1231,7 +1230,7 @@ type DelegateStakingBalanceInput struct {
 	// The cycle to get the balance at. If not provided BlockID is required.
 	Cycle int
 	// The delegate that you want to make the query.
-	Delegate string `validate:"required"`
+	Delegate string `validate:"required,key_hash"`
 }
 
 /*
@@ -1247,21 +1246,21 @@ Path:
 RPC:
 	https://tezos.gitlab.io/008/rpc.html#get-block-id-context-delegates-pkh-staking-balance
 */
-func (c *Client) DelegateStakingBalance(input DelegateStakingBalanceInput) (*resty.Response, string, error) {
+func (c *Client) DelegateStakingBalance(input DelegateStakingBalanceInput) (*resty.Response, Mutez, error) {
 	resp, blockID, err := c.processContextRequest(input, input.Cycle, input.BlockID)
 	if err != nil {
-		return resp, "", errors.Wrap(err, "failed to get delegate staking balance")
+		return resp, Mutez{}, errors.Wrap(err, "failed to get delegate staking balance")
 	}
 
 	resp, err = c.get(fmt.Sprintf("/chains/%s/blocks/%s/context/delegates/%s/staking_balance", c.chain, blockID.ID(), input.Delegate))
 	if err != nil {
-		return resp, "", errors.Wrapf(err, "failed to get delegate '%s' staking balance", input.Delegate)
+		return resp, Mutez{}, errors.Wrapf(err, "failed to get delegate '%s' staking balance", input.Delegate)
 	}
 
-	var stakingBalance string
+	var stakingBalance Mutez
 	err = json.Unmarshal(resp.Body(), &stakingBalance)
 	if err != nil {
-		return resp, "", errors.Wrapf(err, "failed to get delegate '%s' staking balance: failed to parse json", input.Delegate)
+		return resp, Mutez{}, errors.Wrapf(err, "failed to get delegate '%s' staking balance: failed to parse json", input.Delegate)
 	}
 
 	return resp, stakingBalance, nil
@@ -1279,7 +1278,7 @@ type DelegateVotingPowerInput struct {
 	// The cycle to get the balance at. If not provided BlockID is required.
 	Cycle int
 	// The delegate that you want to make the query.
-	Delegate string `validate:"required"`
+	Delegate string `validate:"required,key_hash"`
 }
 
 /*
@@ -0,0 +1,201 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	regRPCError = regexp.MustCompile(`^\s*\[\s*\{[^\[\]{}]*"kind"\s*:`)
+)
+
+/*
+Error represents an RPC error, such as an error of the trace the node returns when it rejects a request, or an
+error of an operation result. The fields common to the node's errors are decoded, and every field the node
+returned is kept in Fields.
+
+RPC:
+	https://tezos.gitlab.io/api/errors.html
+*/
+type Error struct {
+	Kind           string           `json:"kind"`
+	ID             string           `json:"id,omitempty"`
+	Err            string           `json:"error,omitempty"`
+	Msg            string           `json:"msg,omitempty"`
+	Contract       string           `json:"contract,omitempty"`
+	Balance        Mutez            `json:"balance,omitempty"`
+	Amount         Mutez            `json:"amount,omitempty"`
+	Location       int              `json:"location,omitempty"`
+	With           *json.RawMessage `json:"with,omitempty"`
+	ContractHandle string           `json:"contract_handle,omitempty"`
+	ContractCode   *json.RawMessage `json:"contract_code,omitempty"`
+	// Every field of the error as the node returned it, including those above.
+	Fields map[string]json.RawMessage `json:"-"`
+}
+
+type errorFields Error
+
+// UnmarshalJSON satisfies the json.Unmarshaler interface.
+func (r *Error) UnmarshalJSON(v []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(v, &fields); err != nil {
+		return err
+	}
+
+	// errors with fields of unexpected types are still classified by their kind and id
+	if err := json.Unmarshal(v, (*errorFields)(r)); err != nil {
+		*r = Error{}
+		for key, field := range map[string]*string{"kind": &r.Kind, "id": &r.ID, "error": &r.Err, "msg": &r.Msg} {
+			json.Unmarshal(fields[key], field)
+		}
+	}
+	r.Fields = fields
+
+	return nil
+}
+
+func (r *Error) Error() string {
+	if r.Err != "" {
+		return fmt.Sprintf("rpc error (%s): %s", r.Kind, r.Err)
+	}
+
+	if r.Msg != "" {
+		return fmt.Sprintf("rpc error (%s): %s: %s", r.Kind, r.ID, r.Msg)
+	}
+
+	return fmt.Sprintf("rpc error (%s): %s", r.Kind, r.ID)
+}
+
+/*
+Errors represents the trace of errors the node returns, from the outermost to the innermost error. Requests the
+node rejects return Errors, which can be retrieved from the wrapped error with AsErrors.
+*/
+type Errors []Error
+
+func (r Errors) Error() string {
+	errs := make([]string, len(r))
+	for i := range r {
+		errs[i] = r[i].Error()
+	}
+
+	return strings.Join(errs, "; ")
+}
+
+/*
+Find returns the first error with id. The protocol part of the id can be left out, so that
+"contract.balance_too_low" finds "proto.008-PtEdo2Zk.contract.balance_too_low".
+*/
+func (r Errors) Find(id string) (Error, bool) {
+	for _, e := range r {
+		if e.ID == id || strings.HasSuffix(e.ID, "."+id) {
+			return e, true
+		}
+	}
+
+	return Error{}, false
+}
+
+/*
+AsErrors returns the RPC errors err wraps, if any.
+
+Example:
+	_, hash, err := client.InjectionOperation(rpc.InjectionOperationInput{Operation: op})
+	if errs, ok := rpc.AsErrors(err); ok {
+		for _, e := range errs {
+			fmt.Println(e.ID, e.Fields)
+		}
+	}
+*/
+func AsErrors(err error) (Errors, bool) {
+	var errs Errors
+	if errors.As(err, &errs) {
+		return errs, true
+	}
+
+	var e *Error
+	if errors.As(err, &e) {
+		return Errors{*e}, true
+	}
+
+	return nil, false
+}
+
+// IsCounterInThePast reports whether err has a counter_in_the_past error, which means the operation was already included or replaced
+func IsCounterInThePast(err error) bool {
+	return hasError(err, "contract.counter_in_the_past")
+}
+
+// IsCounterInTheFuture reports whether err has a counter_in_the_future error, which means an operation with a lower counter is still pending
+func IsCounterInTheFuture(err error) bool {
+	return hasError(err, "contract.counter_in_the_future")
+}
+
+// IsBalanceTooLow reports whether err has a balance_too_low error
+func IsBalanceTooLow(err error) bool {
+	return hasError(err, "contract.balance_too_low")
+}
+
+// IsGasExhausted reports whether err has an error for exhausting the gas of the operation or of the block
+func IsGasExhausted(err error) bool {
+	return hasError(err, "gas_exhausted.operation") || hasError(err, "gas_exhausted.block")
+}
+
+/*
+ScriptRejected reports whether err has a script_rejected error, which a contract's FAILWITH raises, and returns the
+value the contract failed with as Micheline JSON.
+
+Example:
+	_, err := builder.Build(rpc.Content{...})
+	if with, ok := rpc.ScriptRejected(err); ok {
+		fmt.Printf("contract failed with %s\n", string(*with))
+	}
+*/
+func ScriptRejected(err error) (*json.RawMessage, bool) {
+	errs, ok := AsErrors(err)
+	if !ok {
+		return nil, false
+	}
+
+	e, ok := errs.Find("michelson_v1.script_rejected")
+	if !ok {
+		return nil, false
+	}
+
+	return e.With, true
+}
+
+func hasError(err error, id string) bool {
+	errs, ok := AsErrors(err)
+	if !ok {
+		return false
+	}
+
+	_, ok = errs.Find(id)
+	return ok
+}
+
+// handleRPCError returns the errors in a response body, if it is an error trace
+func handleRPCError(resp []byte) error {
+	if !regRPCError.Match(resp) {
+		return nil
+	}
+
+	var rpcErrors Errors
+	if err := json.Unmarshal(bytes.TrimSpace(resp), &rpcErrors); err != nil {
+		return errors.Wrap(err, "failed to parse rpc error")
+	}
+
+	for _, e := range rpcErrors {
+		if e.ID == "" && e.Err == "" {
+			// a list of objects with a kind that is not an error trace, like operation contents
+			return nil
+		}
+	}
+
+	return rpcErrors
+}
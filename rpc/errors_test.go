@@ -0,0 +1,190 @@
+package rpc_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/goat-systems/go-tezos/v4/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Errors(t *testing.T) {
+	type want struct {
+		err                  bool
+		errContains          string
+		errs                 rpc.Errors
+		counterInThePast     bool
+		counterInTheFuture   bool
+		balanceTooLow        bool
+		gasExhausted         bool
+		scriptRejected       bool
+		scriptRejectedWith   string
+		firstErrorFieldCount int
+	}
+
+	cases := []struct {
+		name  string
+		input string
+		want  want
+	}{
+		{
+			"is successful with balance too low",
+			`[{"kind":"temporary","id":"proto.008-PtEdo2Zk.contract.balance_too_low","contract":"tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc","balance":"1000","amount":"2000"},` +
+				`{"kind":"temporary","id":"proto.008-PtEdo2Zk.tez.subtraction_underflow","amounts":["1000","2000"]}]`,
+			want{
+				err:         true,
+				errContains: "rpc error (temporary): proto.008-PtEdo2Zk.contract.balance_too_low; rpc error (temporary): proto.008-PtEdo2Zk.tez.subtraction_underflow",
+				errs: rpc.Errors{
+					{Kind: "temporary", ID: "proto.008-PtEdo2Zk.contract.balance_too_low", Contract: "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc", Balance: rpc.NewMutez(1000), Amount: rpc.NewMutez(2000)},
+					{Kind: "temporary", ID: "proto.008-PtEdo2Zk.tez.subtraction_underflow"},
+				},
+				balanceTooLow:        true,
+				firstErrorFieldCount: 5,
+			},
+		},
+		{
+			"is successful with counter in the past",
+			`[{"kind":"branch","id":"proto.008-PtEdo2Zk.contract.counter_in_the_past","contract":"tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc","expected":"11","found":"10"}]`,
+			want{
+				err:         true,
+				errContains: "proto.008-PtEdo2Zk.contract.counter_in_the_past",
+				errs: rpc.Errors{
+					{Kind: "branch", ID: "proto.008-PtEdo2Zk.contract.counter_in_the_past", Contract: "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc"},
+				},
+				counterInThePast:     true,
+				firstErrorFieldCount: 5,
+			},
+		},
+		{
+			"is successful with counter in the future",
+			`[{"kind":"temporary","id":"proto.008-PtEdo2Zk.contract.counter_in_the_future","contract":"tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc","expected":"11","found":"12"}]`,
+			want{
+				err:         true,
+				errContains: "proto.008-PtEdo2Zk.contract.counter_in_the_future",
+				errs: rpc.Errors{
+					{Kind: "temporary", ID: "proto.008-PtEdo2Zk.contract.counter_in_the_future", Contract: "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc"},
+				},
+				counterInTheFuture:   true,
+				firstErrorFieldCount: 5,
+			},
+		},
+		{
+			"is successful with gas exhausted",
+			`[{"kind":"temporary","id":"proto.008-PtEdo2Zk.gas_exhausted.operation"}]`,
+			want{
+				err:                  true,
+				errContains:          "proto.008-PtEdo2Zk.gas_exhausted.operation",
+				errs:                 rpc.Errors{{Kind: "temporary", ID: "proto.008-PtEdo2Zk.gas_exhausted.operation"}},
+				gasExhausted:         true,
+				firstErrorFieldCount: 2,
+			},
+		},
+		{
+			"is successful with script rejected",
+			`[{"kind":"temporary","id":"proto.008-PtEdo2Zk.michelson_v1.runtime_error","contract_handle":"KT1Njyz94x2pNJGh5uMhKj24VB9JsGCdkySN","contract_code":[]},` +
+				`{"kind":"temporary","id":"proto.008-PtEdo2Zk.michelson_v1.script_rejected","location":42,"with":{"string":"NotEnoughBalance"}}]`,
+			want{
+				err:         true,
+				errContains: "proto.008-PtEdo2Zk.michelson_v1.script_rejected",
+				errs: rpc.Errors{
+					{Kind: "temporary", ID: "proto.008-PtEdo2Zk.michelson_v1.runtime_error", ContractHandle: "KT1Njyz94x2pNJGh5uMhKj24VB9JsGCdkySN", ContractCode: rawJSON(`[]`)},
+					{Kind: "temporary", ID: "proto.008-PtEdo2Zk.michelson_v1.script_rejected", Location: 42, With: rawJSON(`{"string":"NotEnoughBalance"}`)},
+				},
+				scriptRejected:       true,
+				scriptRejectedWith:   `{"string":"NotEnoughBalance"}`,
+				firstErrorFieldCount: 4,
+			},
+		},
+		{
+			"is successful with fields of unexpected types",
+			`[{"kind":"permanent","id":"failure","msg":"some failure","location":{"line":1}}]`,
+			want{
+				err:                  true,
+				errContains:          "rpc error (permanent): failure: some failure",
+				errs:                 rpc.Errors{{Kind: "permanent", ID: "failure", Msg: "some failure"}},
+				firstErrorFieldCount: 4,
+			},
+		},
+		{
+			"handles malformed error",
+			`[{"kind":"temporary","id":`,
+			want{
+				err:         true,
+				errContains: "failed to parse rpc error",
+			},
+		},
+		{
+			"ignores lists that are not errors",
+			`[{"kind":"incoming"}]`,
+			want{},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(gtGoldenHTTPMock(mockHandler(&requestResultPair{regConnections, []byte(tt.input)}, blankHandler)))
+			defer server.Close()
+
+			r, err := rpc.New(server.URL)
+			checkErr(t, false, "", err)
+
+			_, _, err = r.Connections()
+			checkErr(t, tt.want.err, tt.want.errContains, err)
+
+			errs, ok := rpc.AsErrors(err)
+			assert.Equal(t, tt.want.errs != nil, ok)
+			if ok {
+				assert.Len(t, errs[0].Fields, tt.want.firstErrorFieldCount)
+			}
+
+			// Fields holds the raw json, which is checked by its size above
+			var withoutFields rpc.Errors
+			for _, e := range errs {
+				e.Fields = nil
+				withoutFields = append(withoutFields, e)
+			}
+			assert.Equal(t, tt.want.errs, withoutFields)
+
+			assert.Equal(t, tt.want.counterInThePast, rpc.IsCounterInThePast(err))
+			assert.Equal(t, tt.want.counterInTheFuture, rpc.IsCounterInTheFuture(err))
+			assert.Equal(t, tt.want.balanceTooLow, rpc.IsBalanceTooLow(err))
+			assert.Equal(t, tt.want.gasExhausted, rpc.IsGasExhausted(err))
+
+			with, ok := rpc.ScriptRejected(err)
+			assert.Equal(t, tt.want.scriptRejected, ok)
+			if ok {
+				assert.JSONEq(t, tt.want.scriptRejectedWith, string(*with))
+			}
+		})
+	}
+}
+
+func Test_Errors_Find(t *testing.T) {
+	errs := rpc.Errors{
+		{Kind: "temporary", ID: "proto.008-PtEdo2Zk.contract.balance_too_low"},
+		{Kind: "temporary", ID: "proto.008-PtEdo2Zk.tez.subtraction_underflow"},
+	}
+
+	e, ok := errs.Find("contract.balance_too_low")
+	assert.True(t, ok)
+	assert.Equal(t, errs[0], e)
+
+	e, ok = errs.Find("proto.008-PtEdo2Zk.tez.subtraction_underflow")
+	assert.True(t, ok)
+	assert.Equal(t, errs[1], e)
+
+	_, ok = errs.Find("balance_too_low")
+	assert.True(t, ok)
+
+	_, ok = errs.Find("too_low")
+	assert.False(t, ok)
+
+	_, ok = rpc.AsErrors(&rpc.Error{Kind: "somekind", Err: "someerror"})
+	assert.True(t, ok)
+}
+
+func rawJSON(v string) *json.RawMessage {
+	raw := json.RawMessage(v)
+	return &raw
+}
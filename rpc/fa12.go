@@ -21,13 +21,13 @@ type GetFA12BalanceInput struct {
 	// The cycle to get the balance at. If not provided Blockhash is required.
 	Cycle int
 	// ChainID is the Chain ID of the chain you want to query
-	ChainID string `validate:"required"`
+	ChainID string `validate:"required,chain_id"`
 	// Source to form the contents with. The operation is not forged or injected so it is possible for XTZ to be spent.
-	Source string `validate:"required"`
+	Source string `validate:"required,address"`
 	// FA12Contract address of the FA1.2 Contract you wish to query.
-	FA12Contract string `validate:"required"`
+	FA12Contract string `validate:"required,address"`
 	// OwnerAddress is the address to get the balance for in the FA1.2 contract
-	OwnerAddress string `validate:"required"`
+	OwnerAddress string `validate:"required,address"`
 	// If true the function will use an intermediate contract deployed on Carthagenet, default mainnet.
 	Testnet bool
 	// If provided this will be the contract view address used to query the FA1.2 contract
@@ -46,11 +46,11 @@ type GetFA12SupplyInput struct {
 	// The cycle to get the balance at. If not provided Blockhash is required.
 	Cycle int
 	// ChainID is the Chain ID of the chain you want to query
-	ChainID string `validate:"required"`
+	ChainID string `validate:"required,chain_id"`
 	// Source to form the contents with. The operation is not forged or injected so it is possible for XTZ to be spent.
-	Source string `validate:"required"`
+	Source string `validate:"required,address"`
 	// FA12Contract address of the FA1.2 Contract you wish to query.
-	FA12Contract string `validate:"required"`
+	FA12Contract string `validate:"required,address"`
 	// If true the function will use an intermediate contract deployed on Carthagenet, default mainnet.
 	Testnet bool
 	// If provided this will be the contract view address used to query the FA1.2 contract
@@ -69,15 +69,15 @@ type GetFA12AllowanceInput struct {
 	// The cycle to get the balance at. If not provided Blockhash is required.
 	Cycle int
 	// ChainID is the Chain ID of the chain you want to query
-	ChainID string `validate:"required"`
+	ChainID string `validate:"required,chain_id"`
 	// Source to form the contents with. The operation is not forged or injected so it is possible for XTZ to be spent.
-	Source string `validate:"required"`
+	Source string `validate:"required,address"`
 	// FA12Contract address of the FA1.2 Contract you wish to query.
-	FA12Contract string `validate:"required"`
+	FA12Contract string `validate:"required,address"`
 	// OwnerAddress is the address to get the balance for in the FA1.2 contract
-	OwnerAddress string `validate:"required"`
+	OwnerAddress string `validate:"required,address"`
 	// SpenderAddress is the address to check an allowance for on behalf of an owner
-	SpenderAddress string `validate:"required"`
+	SpenderAddress string `validate:"required,address"`
 	// If true the function will use an intermediate contract deployed on Carthagenet, default mainnet.
 	Testnet bool
 	// If provided this will be the contract view address used to query the FA1.2 contract
@@ -224,10 +224,10 @@ func (c *Client) GetFA12Balance(input GetFA12BalanceInput) (*resty.Response, str
 			Kind:         TRANSACTION,
 			Source:       input.Source,
 			Destination:  input.ContractViewAddress,
-			Fee:          "0",
+			Fee:          NewMutez(0),
 			GasLimit:     "1040000",
 			StorageLimit: "60000",
-			Amount:       "0",
+			Amount:       NewMutez(0),
 			Counter:      strconv.Itoa(counter),
 			Parameters: &Parameters{
 				Entrypoint: "default",
@@ -297,10 +297,10 @@ func (c *Client) GetFA12Supply(input GetFA12SupplyInput) (*resty.Response, strin
 			Kind:         TRANSACTION,
 			Source:       input.Source,
 			Destination:  input.ContractViewAddress,
-			Fee:          "0",
+			Fee:          NewMutez(0),
 			GasLimit:     "1040000",
 			StorageLimit: "60000",
-			Amount:       "0",
+			Amount:       NewMutez(0),
 			Counter:      strconv.Itoa(counter),
 			Parameters: &Parameters{
 				Entrypoint: "default",
@@ -365,10 +365,10 @@ func (c *Client) GetFA12Allowance(input GetFA12AllowanceInput) (*resty.Response,
 			Kind:         TRANSACTION,
 			Source:       input.Source,
 			Destination:  input.ContractViewAddress,
-			Fee:          "0",
+			Fee:          NewMutez(0),
 			GasLimit:     "1040000",
 			StorageLimit: "60000",
-			Amount:       "0",
+			Amount:       NewMutez(0),
 			Counter:      strconv.Itoa(counter),
 			Parameters: &Parameters{
 				Entrypoint: "default",
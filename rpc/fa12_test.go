@@ -44,10 +44,10 @@ func Test_GetFA12Balance(t *testing.T) {
 				gtGoldenHTTPMock(mockCycleFailed(blankHandler)),
 				rpc.GetFA12BalanceInput{
 					Cycle:        1,
-					ChainID:      "some_chainid",
-					Source:       "some_source",
-					FA12Contract: "some_fa1.2_contract",
-					OwnerAddress: "some_address",
+					ChainID:      "NetXdQprcVkpaWU",
+					Source:       "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc",
+					FA12Contract: "KT1DrJV8vhkdLEj76h1H9Q4irZDqAkMPo1Qf",
+					OwnerAddress: "tz1U8sXoQWGUMQrfZeAYwAzMZUvWwy7mfpPQ",
 				},
 			},
 			want{
@@ -62,15 +62,15 @@ func Test_GetFA12Balance(t *testing.T) {
 				gtGoldenHTTPMock(mockCycleSuccessful(mockHandler(&requestResultPair{regContractCounter, []byte(`junk`)}, blankHandler))),
 				rpc.GetFA12BalanceInput{
 					Cycle:        1,
-					ChainID:      "some_chainid",
-					Source:       "some_source",
-					FA12Contract: "some_fa1.2_contract",
-					OwnerAddress: "some_address",
+					ChainID:      "NetXdQprcVkpaWU",
+					Source:       "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc",
+					FA12Contract: "KT1DrJV8vhkdLEj76h1H9Q4irZDqAkMPo1Qf",
+					OwnerAddress: "tz1U8sXoQWGUMQrfZeAYwAzMZUvWwy7mfpPQ",
 				},
 			},
 			want{
 				true,
-				"failed to get counter for contract 'some_source': failed to parse json",
+				"failed to get counter for contract 'tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc': failed to parse json",
 				"0",
 			},
 		},
@@ -86,10 +86,10 @@ func Test_GetFA12Balance(t *testing.T) {
 				))),
 				rpc.GetFA12BalanceInput{
 					Cycle:        1,
-					ChainID:      "some_chainid",
-					Source:       "some_source",
-					FA12Contract: "some_fa1.2_contract",
-					OwnerAddress: "some_address",
+					ChainID:      "NetXdQprcVkpaWU",
+					Source:       "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc",
+					FA12Contract: "KT1DrJV8vhkdLEj76h1H9Q4irZDqAkMPo1Qf",
+					OwnerAddress: "tz1U8sXoQWGUMQrfZeAYwAzMZUvWwy7mfpPQ",
 					Testnet:      true,
 				},
 			},
@@ -111,10 +111,10 @@ func Test_GetFA12Balance(t *testing.T) {
 				))),
 				rpc.GetFA12BalanceInput{
 					Cycle:        1,
-					ChainID:      "some_chainid",
-					Source:       "some_source",
-					FA12Contract: "some_fa1.2_contract",
-					OwnerAddress: "some_address",
+					ChainID:      "NetXdQprcVkpaWU",
+					Source:       "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc",
+					FA12Contract: "KT1DrJV8vhkdLEj76h1H9Q4irZDqAkMPo1Qf",
+					OwnerAddress: "tz1U8sXoQWGUMQrfZeAYwAzMZUvWwy7mfpPQ",
 					Testnet:      true,
 				},
 			},
@@ -136,10 +136,10 @@ func Test_GetFA12Balance(t *testing.T) {
 				))),
 				rpc.GetFA12BalanceInput{
 					Cycle:        1,
-					ChainID:      "some_chainid",
-					Source:       "some_source",
-					FA12Contract: "some_fa1.2_contract",
-					OwnerAddress: "some_address",
+					ChainID:      "NetXdQprcVkpaWU",
+					Source:       "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc",
+					FA12Contract: "KT1DrJV8vhkdLEj76h1H9Q4irZDqAkMPo1Qf",
+					OwnerAddress: "tz1U8sXoQWGUMQrfZeAYwAzMZUvWwy7mfpPQ",
 					Testnet:      true,
 				},
 			},
@@ -201,9 +201,9 @@ func Test_GetFA12Supply(t *testing.T) {
 				gtGoldenHTTPMock(mockCycleFailed(blankHandler)),
 				rpc.GetFA12SupplyInput{
 					Cycle:        10,
-					Source:       "some_source",
-					FA12Contract: "some_fa1.2_contract",
-					ChainID:      "some_chainid",
+					Source:       "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc",
+					FA12Contract: "KT1DrJV8vhkdLEj76h1H9Q4irZDqAkMPo1Qf",
+					ChainID:      "NetXdQprcVkpaWU",
 				},
 			},
 			want{
@@ -221,9 +221,9 @@ func Test_GetFA12Supply(t *testing.T) {
 				))),
 				rpc.GetFA12SupplyInput{
 					BlockID:      &rpc.BlockIDHead{},
-					Source:       "some_source",
-					FA12Contract: "some_fa1.2_contract",
-					ChainID:      "some_chainid",
+					Source:       "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc",
+					FA12Contract: "KT1DrJV8vhkdLEj76h1H9Q4irZDqAkMPo1Qf",
+					ChainID:      "NetXdQprcVkpaWU",
 				},
 			},
 			want{
@@ -244,10 +244,10 @@ func Test_GetFA12Supply(t *testing.T) {
 				))),
 				rpc.GetFA12SupplyInput{
 					Cycle:        10,
-					Source:       "some_source",
-					FA12Contract: "some_fa1.2_contract",
+					Source:       "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc",
+					FA12Contract: "KT1DrJV8vhkdLEj76h1H9Q4irZDqAkMPo1Qf",
 					Testnet:      true,
-					ChainID:      "some_chainid",
+					ChainID:      "NetXdQprcVkpaWU",
 				},
 			},
 			want{
@@ -268,10 +268,10 @@ func Test_GetFA12Supply(t *testing.T) {
 				))),
 				rpc.GetFA12SupplyInput{
 					Cycle:        10,
-					Source:       "some_source",
-					FA12Contract: "some_fa1.2_contract",
+					Source:       "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc",
+					FA12Contract: "KT1DrJV8vhkdLEj76h1H9Q4irZDqAkMPo1Qf",
 					Testnet:      true,
-					ChainID:      "some_chainid",
+					ChainID:      "NetXdQprcVkpaWU",
 				},
 			},
 			want{
@@ -292,10 +292,10 @@ func Test_GetFA12Supply(t *testing.T) {
 				))),
 				rpc.GetFA12SupplyInput{
 					Cycle:        10,
-					Source:       "some_source",
-					FA12Contract: "some_fa1.2_contract",
+					Source:       "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc",
+					FA12Contract: "KT1DrJV8vhkdLEj76h1H9Q4irZDqAkMPo1Qf",
 					Testnet:      true,
-					ChainID:      "some_chainid",
+					ChainID:      "NetXdQprcVkpaWU",
 				},
 			},
 			want{
@@ -316,10 +316,10 @@ func Test_GetFA12Supply(t *testing.T) {
 				))),
 				rpc.GetFA12SupplyInput{
 					Cycle:        10,
-					Source:       "some_source",
-					FA12Contract: "some_fa1.2_contract",
+					Source:       "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc",
+					FA12Contract: "KT1DrJV8vhkdLEj76h1H9Q4irZDqAkMPo1Qf",
 					Testnet:      true,
-					ChainID:      "some_chainid",
+					ChainID:      "NetXdQprcVkpaWU",
 				},
 			},
 			want{
@@ -380,11 +380,11 @@ func Test_GetFA12Allowance(t *testing.T) {
 				gtGoldenHTTPMock(mockCycleFailed(blankHandler)),
 				rpc.GetFA12AllowanceInput{
 					Cycle:          1,
-					ChainID:        "some_chainid",
-					Source:         "some_source",
-					FA12Contract:   "some_fa1.2_contract",
-					OwnerAddress:   "some_address",
-					SpenderAddress: "some_address",
+					ChainID:        "NetXdQprcVkpaWU",
+					Source:         "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc",
+					FA12Contract:   "KT1DrJV8vhkdLEj76h1H9Q4irZDqAkMPo1Qf",
+					OwnerAddress:   "tz1U8sXoQWGUMQrfZeAYwAzMZUvWwy7mfpPQ",
+					SpenderAddress: "tz1U8sXoQWGUMQrfZeAYwAzMZUvWwy7mfpPQ",
 				},
 			},
 			want{
@@ -402,11 +402,11 @@ func Test_GetFA12Allowance(t *testing.T) {
 				))),
 				rpc.GetFA12AllowanceInput{
 					Cycle:          1,
-					ChainID:        "some_chainid",
-					Source:         "some_source",
-					FA12Contract:   "some_fa1.2_contract",
-					OwnerAddress:   "some_address",
-					SpenderAddress: "some_address",
+					ChainID:        "NetXdQprcVkpaWU",
+					Source:         "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc",
+					FA12Contract:   "KT1DrJV8vhkdLEj76h1H9Q4irZDqAkMPo1Qf",
+					OwnerAddress:   "tz1U8sXoQWGUMQrfZeAYwAzMZUvWwy7mfpPQ",
+					SpenderAddress: "tz1U8sXoQWGUMQrfZeAYwAzMZUvWwy7mfpPQ",
 				},
 			},
 			want{
@@ -427,12 +427,12 @@ func Test_GetFA12Allowance(t *testing.T) {
 				))),
 				rpc.GetFA12AllowanceInput{
 					Cycle:          1,
-					ChainID:        "some_chainid",
-					Source:         "some_source",
-					FA12Contract:   "some_fa1.2_contract",
-					OwnerAddress:   "some_address",
+					ChainID:        "NetXdQprcVkpaWU",
+					Source:         "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc",
+					FA12Contract:   "KT1DrJV8vhkdLEj76h1H9Q4irZDqAkMPo1Qf",
+					OwnerAddress:   "tz1U8sXoQWGUMQrfZeAYwAzMZUvWwy7mfpPQ",
 					Testnet:        true,
-					SpenderAddress: "some_address",
+					SpenderAddress: "tz1U8sXoQWGUMQrfZeAYwAzMZUvWwy7mfpPQ",
 				},
 			},
 			want{
@@ -453,12 +453,12 @@ func Test_GetFA12Allowance(t *testing.T) {
 				))),
 				rpc.GetFA12AllowanceInput{
 					Cycle:          1,
-					ChainID:        "some_chainid",
-					Source:         "some_source",
-					FA12Contract:   "some_fa1.2_contract",
-					OwnerAddress:   "some_address",
+					ChainID:        "NetXdQprcVkpaWU",
+					Source:         "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc",
+					FA12Contract:   "KT1DrJV8vhkdLEj76h1H9Q4irZDqAkMPo1Qf",
+					OwnerAddress:   "tz1U8sXoQWGUMQrfZeAYwAzMZUvWwy7mfpPQ",
 					Testnet:        true,
-					SpenderAddress: "some_address",
+					SpenderAddress: "tz1U8sXoQWGUMQrfZeAYwAzMZUvWwy7mfpPQ",
 				},
 			},
 			want{
@@ -479,12 +479,12 @@ func Test_GetFA12Allowance(t *testing.T) {
 				))),
 				rpc.GetFA12AllowanceInput{
 					Cycle:          1,
-					ChainID:        "some_chainid",
-					Source:         "some_source",
-					FA12Contract:   "some_fa1.2_contract",
-					OwnerAddress:   "some_address",
+					ChainID:        "NetXdQprcVkpaWU",
+					Source:         "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc",
+					FA12Contract:   "KT1DrJV8vhkdLEj76h1H9Q4irZDqAkMPo1Qf",
+					OwnerAddress:   "tz1U8sXoQWGUMQrfZeAYwAzMZUvWwy7mfpPQ",
 					Testnet:        true,
-					SpenderAddress: "some_address",
+					SpenderAddress: "tz1U8sXoQWGUMQrfZeAYwAzMZUvWwy7mfpPQ",
 				},
 			},
 			want{
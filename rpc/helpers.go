@@ -8,7 +8,6 @@ import (
 	"strconv"
 	"time"
 
-	validator "github.com/go-playground/validator/v10"
 	"github.com/go-resty/resty/v2"
 	"github.com/goat-systems/go-tezos/v4/internal/crypto"
 	"github.com/pkg/errors"
@@ -109,7 +108,7 @@ RPC:
 	https://tezos.gitlab.io/008/rpc.html#get-block-id-helpers-baking-rights
 */
 func (c *Client) BakingRights(input BakingRightsInput) (*resty.Response, []BakingRights, error) {
-	err := validator.New().Struct(input)
+	err := validateInput(input)
 	if err != nil {
 		return nil, []BakingRights{}, errors.Wrap(err, "failed to get baking rights: invalid input")
 	}
@@ -151,7 +150,7 @@ RPC:
 	https://tezos.gitlab.io/008/rpc.html#get-block-id-helpers-complete-prefix
 */
 func (c *Client) CompletePrefix(input CompletePrefixInput) (*resty.Response, []string, error) {
-	err := validator.New().Struct(input)
+	err := validateInput(input)
 	if err != nil {
 		return nil, []string{}, errors.Wrap(err, "failed to complete prefix: invalid input")
 	}
@@ -227,7 +226,7 @@ RPC:
 	https://tezos.gitlab.io/008/rpc.html#get-block-id-helpers-current-level
 */
 func (c *Client) CurrentLevel(input CurrentLevelInput) (*resty.Response, CurrentLevel, error) {
-	err := validator.New().Struct(input)
+	err := validateInput(input)
 	if err != nil {
 		return nil, CurrentLevel{}, errors.Wrap(err, "failed to get current level: invalid input")
 	}
@@ -297,7 +296,7 @@ RPC:
 	https://tezos.gitlab.io/008/rpc.html#get-block-id-helpers-endorsing-rights
 */
 func (c *Client) EndorsingRights(input EndorsingRightsInput) (*resty.Response, []EndorsingRights, error) {
-	err := validator.New().Struct(input)
+	err := validateInput(input)
 	if err != nil {
 		return nil, []EndorsingRights{}, errors.Wrap(err, "failed to get endorsing rightsL invalid input")
 	}
@@ -352,7 +351,7 @@ RPC:
 type ForgeOperationsInput struct {
 	// The hash of block (height) of which you want to make the query.
 	BlockIDHash BlockIDHash `validate:"required"`
-	Branch      string      `validate:"required"`
+	Branch      string      `validate:"required,block_hash"`
 	Contents    Contents    `validate:"required"`
 	// Using the RPC to forge an operation is dangerous, you can mitigate this
 	// danger by passing a different host to CheckRPCAddr which will unforge the
@@ -372,7 +371,7 @@ RPC:
 
 */
 func (c *Client) ForgeOperations(input ForgeOperationsInput) (*resty.Response, string, error) {
-	err := validator.New().Struct(input)
+	err := validateInput(input)
 	if err != nil {
 		return nil, "", errors.Wrap(err, "failed to forge operation: invalid input")
 	}
@@ -409,6 +408,7 @@ func (c *Client) ForgeOperations(input ForgeOperationsInput) (*resty.Response, s
 		if err != nil {
 			return resp, operation, errors.Wrap(err, "failed to forge operation: unable to verify rpc returned a valid contents with alternative node")
 		}
+		rpc.ctx = c.ctx
 	} else {
 		rpc = c
 	}
@@ -490,7 +490,7 @@ RPC:
 	https://tezos.gitlab.io/008/rpc.html#post-block-id-helpers-forge-block-header
 */
 func (c *Client) ForgeBlockHeader(input ForgeBlockHeaderInput) (*resty.Response, ForgeBlockHeader, error) {
-	err := validator.New().Struct(input)
+	err := validateInput(input)
 	if err != nil {
 		return nil, ForgeBlockHeader{}, errors.Wrap(err, "failed to forge block header: invalid input")
 	}
@@ -542,7 +542,7 @@ RPC:
 	https://tezos.gitlab.io/008/rpc.html#get-block-id-helpers-levels-in-current-cycle
 */
 func (c *Client) LevelsInCurrentCycle(input LevelsInCurrentCycleInput) (*resty.Response, LevelsInCurrentCycle, error) {
-	err := validator.New().Struct(input)
+	err := validateInput(input)
 	if err != nil {
 		return nil, LevelsInCurrentCycle{}, errors.Wrap(err, "failed to get levels in current cycle: invalid input")
 	}
@@ -596,7 +596,7 @@ RPC:
 	https://tezos.gitlab.io/008/rpc.html#post-block-id-helpers-parse-block
 */
 func (c *Client) ParseBlock(input ParseBlockInput) (*resty.Response, BlockHeaderSignedContents, error) {
-	err := validator.New().Struct(input)
+	err := validateInput(input)
 	if err != nil {
 		return nil, BlockHeaderSignedContents{}, errors.Wrap(err, "failed to parse block: invalid input")
 	}
@@ -650,7 +650,7 @@ RPC:
 	https://tezos.gitlab.io/008/rpc.html#post-block-id-helpers-parse-operations
 */
 func (c *Client) ParseOperations(input ParseOperationsInput) (*resty.Response, []Operations, error) {
-	err := validator.New().Struct(input)
+	err := validateInput(input)
 	if err != nil {
 		return nil, []Operations{}, errors.Wrap(err, "failed to parse operations: invalid input")
 	}
@@ -783,7 +783,7 @@ RPC:
 	https://tezos.gitlab.io/008/rpc.html#post-block-id-helpers-preapply-block
 */
 func (c *Client) PreapplyBlock(input PreapplyBlockInput) (*resty.Response, PreappliedBlock, error) {
-	err := validator.New().Struct(input)
+	err := validateInput(input)
 	if err != nil {
 		return nil, PreappliedBlock{}, errors.Wrap(err, "failed to preapply block: invalid input")
 	}
@@ -825,7 +825,7 @@ RPC:
 	https://tezos.gitlab.io/008/rpc.html#post-block-id-helpers-preapply-operations
 */
 func (c *Client) PreapplyOperations(input PreapplyOperationsInput) (*resty.Response, []Operations, error) {
-	err := validator.New().Struct(input)
+	err := validateInput(input)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "failed to preapply operations: invalid input")
 	}
@@ -888,7 +888,7 @@ RPC:
 	https://tezos.gitlab.io/008/rpc.html#post-block-id-helpers-scripts-entrypoint
 */
 func (c *Client) Entrypoint(input EntrypointInput) (*resty.Response, Entrypoint, error) {
-	err := validator.New().Struct(input)
+	err := validateInput(input)
 	if err != nil {
 		return nil, Entrypoint{}, errors.Wrap(err, "failed to get entrypoint type: invalid input")
 	}
@@ -961,7 +961,7 @@ RPC:
 	https://tezos.gitlab.io/008/rpc.html#post-block-id-helpers-scripts-entrypoints
 */
 func (c *Client) Entrypoints(input EntrypointsInput) (*resty.Response, Entrypoints, error) {
-	err := validator.New().Struct(input)
+	err := validateInput(input)
 	if err != nil {
 		return nil, Entrypoints{}, errors.Wrap(err, "failed to get entrypoints: invalid input")
 	}
@@ -1026,7 +1026,7 @@ RPC:
 	https://tezos.gitlab.io/008/rpc.html#post-block-id-helpers-scripts-pack-data
 */
 func (c *Client) PackData(input PackDataInput) (*resty.Response, PackedData, error) {
-	err := validator.New().Struct(input)
+	err := validateInput(input)
 	if err != nil {
 		return nil, PackedData{}, errors.Wrap(err, "failed to pack data: invalid input")
 	}
@@ -1068,8 +1068,8 @@ type RunCodeBody struct {
 	Script     *json.RawMessage `json:"script"`
 	Storage    *json.RawMessage `json:"storage"`
 	Input      *json.RawMessage `json:"input"`
-	Amount     string           `json:"amount"`
-	Balance    string           `json:"balance"`
+	Amount     Mutez            `json:"amount"`
+	Balance    Mutez            `json:"balance"`
 	ChainID    string           `json:"chain_id"`
 	Source     string           `json:"source,omitempty"`
 	Payer      string           `json:"payer,omitempty"`
@@ -1099,7 +1099,7 @@ RPC:
 	https://tezos.gitlab.io/008/rpc.html#post-block-id-helpers-scripts-run-code
 */
 func (c *Client) RunCode(input RunCodeInput) (*resty.Response, RanCode, error) {
-	err := validator.New().Struct(input)
+	err := validateInput(input)
 	if err != nil {
 		return nil, RanCode{}, errors.Wrap(err, "failed to run code: invalid input")
 	}
@@ -1139,7 +1139,7 @@ RPC:
 */
 type RunOperation struct {
 	Operation Operations `json:"operation" validate:"required"`
-	ChainID   string     `json:"chain_id" validate:"required"`
+	ChainID   string     `json:"chain_id" validate:"required,chain_id"`
 }
 
 /*
@@ -1152,7 +1152,7 @@ RPC:
 	https://tezos.gitlab.io/008/rpc.html#post-block-id-helpers-scripts-run-operation
 */
 func (c *Client) RunOperation(input RunOperationInput) (*resty.Response, Operations, error) {
-	err := validator.New().Struct(input)
+	err := validateInput(input)
 	if err != nil {
 		return nil, Operations{}, errors.Wrap(err, "failed to run operation: invalid input")
 	}
@@ -1228,7 +1228,7 @@ RPC:
 	https://tezos.gitlab.io/008/rpc.html#post-block-id-helpers-scripts-trace-code
 */
 func (c *Client) TraceCode(input TraceCodeInput) (*resty.Response, TracedCode, error) {
-	err := validator.New().Struct(input)
+	err := validateInput(input)
 	if err != nil {
 		return nil, TracedCode{}, errors.Wrap(err, "failed to trace code: invalid input")
 	}
@@ -1297,7 +1297,7 @@ RPC:
 	https://tezos.gitlab.io/008/rpc.html#post-block-id-helpers-scripts-typecheck-code
 */
 func (c *Client) TypecheckCode(input TypeCheckcodeInput) (*resty.Response, TypecheckedCode, error) {
-	err := validator.New().Struct(input)
+	err := validateInput(input)
 	if err != nil {
 		return nil, TypecheckedCode{}, errors.Wrap(err, "failed to typecheck code: invalid input")
 	}
@@ -1362,7 +1362,7 @@ RPC:
 	https://tezos.gitlab.io/008/rpc.html#post-block-id-helpers-scripts-typecheck-data
 */
 func (c *Client) TypecheckData(input TypecheckDataInput) (*resty.Response, TypecheckedData, error) {
-	err := validator.New().Struct(input)
+	err := validateInput(input)
 	if err != nil {
 		return nil, TypecheckedData{}, errors.Wrap(err, "failed to typecheck data: invalid input")
 	}
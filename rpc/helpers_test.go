@@ -216,7 +216,7 @@ func Test_ForgeOperations(t *testing.T) {
 				gtGoldenHTTPMock(mockHandler(&requestResultPair{regForgeOperationWithRPC, readResponse(rpcerrors)}, blankHandler)),
 				rpc.ForgeOperationsInput{
 					BlockIDHash: goldenHash,
-					Branch:      "some_branch",
+					Branch:      "BLz6yCE4BUL4ppo1zsEWdK9FRCt15WAY7ECQcuK9RtWg4xeEVL7",
 					Contents:    rpc.Contents{},
 				},
 			},
@@ -232,7 +232,7 @@ func Test_ForgeOperations(t *testing.T) {
 				gtGoldenHTTPMock(mockHandler(&requestResultPair{regForgeOperationWithRPC, []byte(`junk`)}, blankHandler)),
 				rpc.ForgeOperationsInput{
 					BlockIDHash: goldenHash,
-					Branch:      "some_branch",
+					Branch:      "BLz6yCE4BUL4ppo1zsEWdK9FRCt15WAY7ECQcuK9RtWg4xeEVL7",
 					Contents:    rpc.Contents{},
 				},
 			},
@@ -248,7 +248,7 @@ func Test_ForgeOperations(t *testing.T) {
 				gtGoldenHTTPMock(mockHandler(&requestResultPair{regForgeOperationWithRPC, []byte(`"some_junk_op_string"`)}, mockHandler(&requestResultPair{regParseOperations, readResponse(rpcerrors)}, blankHandler))),
 				rpc.ForgeOperationsInput{
 					BlockIDHash: goldenHash,
-					Branch:      "some_branch",
+					Branch:      "BLz6yCE4BUL4ppo1zsEWdK9FRCt15WAY7ECQcuK9RtWg4xeEVL7",
 					Contents:    rpc.Contents{},
 				},
 			},
@@ -264,7 +264,7 @@ func Test_ForgeOperations(t *testing.T) {
 				gtGoldenHTTPMock(mockHandler(&requestResultPair{regForgeOperationWithRPC, []byte(`"some_operation_string"`)}, mockHandler(&requestResultPair{regParseOperations, readResponse(rpcerrors)}, blankHandler))),
 				rpc.ForgeOperationsInput{
 					BlockIDHash: goldenHash,
-					Branch:      "some_branch",
+					Branch:      "BLz6yCE4BUL4ppo1zsEWdK9FRCt15WAY7ECQcuK9RtWg4xeEVL7",
 					Contents:    rpc.Contents{},
 				},
 			},
@@ -280,15 +280,15 @@ func Test_ForgeOperations(t *testing.T) {
 				gtGoldenHTTPMock(mockHandler(&requestResultPair{regForgeOperationWithRPC, goldenOperationBytes}, mockHandler(&requestResultPair{regParseOperations, readResponse(parseOperations)}, blankHandler))),
 				rpc.ForgeOperationsInput{
 					BlockIDHash: goldenHash,
-					Branch:      "some_branch",
+					Branch:      "BLz6yCE4BUL4ppo1zsEWdK9FRCt15WAY7ECQcuK9RtWg4xeEVL7",
 					Contents: rpc.Contents{
 						{
 							Source:       "tz1LSAycAVcNdYnXCy18bwVksXci8gUC2YpA",
-							Fee:          "100",
+							Fee:          rpc.NewMutez(100),
 							Counter:      "10",
 							GasLimit:     "10100",
 							StorageLimit: "0",
-							Amount:       "12345",
+							Amount:       rpc.NewMutez(12345),
 							Destination:  "tz1LSAycAVcNdYnXCy18bwVksXci8gUC2YpA",
 							Kind:         rpc.TRANSACTION,
 						},
@@ -307,15 +307,15 @@ func Test_ForgeOperations(t *testing.T) {
 				gtGoldenHTTPMock(mockHandler(&requestResultPair{regForgeOperationWithRPC, goldenOperationBytes}, mockHandler(&requestResultPair{regParseOperations, readResponse(parseOperations)}, blankHandler))),
 				rpc.ForgeOperationsInput{
 					BlockIDHash: goldenHash,
-					Branch:      "some_branch",
+					Branch:      "BLz6yCE4BUL4ppo1zsEWdK9FRCt15WAY7ECQcuK9RtWg4xeEVL7",
 					Contents: rpc.Contents{
 						{
 							Source:       "tz1LSAycAVcNdYnXCy18bwVksXci8gUC2YpA",
-							Fee:          "10100",
+							Fee:          rpc.NewMutez(10100),
 							Counter:      "10",
 							GasLimit:     "10100",
 							StorageLimit: "0",
-							Amount:       "12345",
+							Amount:       rpc.NewMutez(12345),
 							Destination:  "tz1LSAycAVcNdYnXCy18bwVksXci8gUC2YpA",
 							Kind:         rpc.TRANSACTION,
 						},
@@ -615,11 +615,11 @@ func Test_ParseOperations(t *testing.T) {
 							{
 								Kind:         "transaction",
 								Source:       "tz1LSAycAVcNdYnXCy18bwVksXci8gUC2YpA",
-								Fee:          "10100",
+								Fee:          rpc.NewMutez(10100),
 								Counter:      "10",
 								GasLimit:     "10100",
 								StorageLimit: "0",
-								Amount:       "12345",
+								Amount:       rpc.NewMutez(12345),
 								Destination:  "tz1LSAycAVcNdYnXCy18bwVksXci8gUC2YpA",
 								Delegate:     "",
 								Secret:       "",
@@ -787,25 +787,25 @@ func Test_PreapplyOperation(t *testing.T) {
 							{
 								Kind:         "transaction",
 								Source:       "tz1W3HW533csCBLor4NPtU79R2TT2sbKfJDH",
-								Fee:          "3000",
+								Fee:          rpc.NewMutez(3000),
 								Counter:      "1263232",
 								GasLimit:     "20000",
 								StorageLimit: "0",
-								Amount:       "50",
+								Amount:       rpc.NewMutez(50),
 								Destination:  "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc",
 								Metadata: &rpc.ContentsMetadata{
 									BalanceUpdates: []rpc.BalanceUpdates{
 										{
 											Kind:     "contract",
 											Contract: "tz1W3HW533csCBLor4NPtU79R2TT2sbKfJDH",
-											Change:   "-3000",
+											Change:   rpc.NewMutez(-3000),
 										},
 										{
 											Kind:     "freezer",
 											Category: "fees",
 											Delegate: "tz1Ke2h7sDdakHJQh8WX4Z372du1KChsksyU",
 											Cycle:    229,
-											Change:   "3000",
+											Change:   rpc.NewMutez(3000),
 										},
 									},
 									OperationResults: &rpc.OperationResults{
@@ -814,12 +814,12 @@ func Test_PreapplyOperation(t *testing.T) {
 											{
 												Kind:     "contract",
 												Contract: "tz1W3HW533csCBLor4NPtU79R2TT2sbKfJDH",
-												Change:   "-50",
+												Change:   rpc.NewMutez(-50),
 											},
 											{
 												Kind:     "contract",
 												Contract: "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc",
-												Change:   "50",
+												Change:   rpc.NewMutez(50),
 											},
 										},
 										ConsumedGas: "10207",
@@ -1138,7 +1138,7 @@ func Test_RunOperation(t *testing.T) {
 			_, operations, err := r.RunOperation(rpc.RunOperationInput{
 				BlockID: &rpc.BlockIDHead{},
 				Operation: rpc.RunOperation{
-					ChainID:   "some_chain_id",
+					ChainID:   "NetXdQprcVkpaWU",
 					Operation: rpc.Operations{},
 				},
 			})
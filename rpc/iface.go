@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"context"
 	"encoding/json"
 	"time"
 
@@ -9,6 +10,8 @@ import (
 
 // IFace is an interface mocking a GoTezos object.
 type IFace interface {
+	WithContext(ctx context.Context) IFace
+	Context() context.Context
 	Block(blockID BlockID) (*resty.Response, *Block, error)
 	EndorsingPower(input EndorsingPowerInput) (*resty.Response, int, error)
 	Hash(blockID BlockID) (*resty.Response, string, error)
@@ -31,7 +34,7 @@ type IFace interface {
 	Constants(input ConstantsInput) (*resty.Response, Constants, error)
 	Contracts(input ContractsInput) (*resty.Response, []string, error)
 	Contract(input ContractInput) (*resty.Response, Contract, error)
-	ContractBalance(input ContractBalanceInput) (*resty.Response, string, error)
+	ContractBalance(input ContractBalanceInput) (*resty.Response, Mutez, error)
 	ContractCounter(input ContractCounterInput) (*resty.Response, int, error)
 	ContractDelegate(input ContractDelegateInput) (*resty.Response, string, error)
 	ContractEntrypoints(input ContractEntrypointsInput) (*resty.Response, map[string]*json.RawMessage, error)
@@ -42,14 +45,14 @@ type IFace interface {
 	ContractStorage(input ContractStorageInput) (*resty.Response, error)
 	Delegates(input DelegatesInput) (*resty.Response, []string, error)
 	Delegate(input DelegateInput) (*resty.Response, Delegate, error)
-	DelegateBalance(input DelegateBalanceInput) (*resty.Response, string, error)
+	DelegateBalance(input DelegateBalanceInput) (*resty.Response, Mutez, error)
 	DelegateDeactivated(input DelegateDeactivatedInput) (*resty.Response, bool, error)
-	DelegateDelegatedBalance(input DelegateDelegatedBalanceInput) (*resty.Response, string, error)
+	DelegateDelegatedBalance(input DelegateDelegatedBalanceInput) (*resty.Response, Mutez, error)
 	DelegateDelegatedContracts(input DelegateDelegatedContractsInput) (*resty.Response, []string, error)
-	DelegateFrozenBalance(input DelegateFrozenBalanceInput) (*resty.Response, string, error)
+	DelegateFrozenBalance(input DelegateFrozenBalanceInput) (*resty.Response, Mutez, error)
 	DelegateFrozenBalanceByCycle(input DelegateFrozenBalanceByCycleInput) (*resty.Response, []FrozenBalanceByCycle, error)
 	DelegateGracePeriod(input DelegateGracePeriodInput) (*resty.Response, int, error)
-	DelegateStakingBalance(input DelegateStakingBalanceInput) (*resty.Response, string, error)
+	DelegateStakingBalance(input DelegateStakingBalanceInput) (*resty.Response, Mutez, error)
 	DelegateVotingPower(input DelegateVotingPowerInput) (*resty.Response, int, error)
 	Nonces(input NoncesInput) (*resty.Response, Nonces, error)
 	RawBytes(input RawBytesInput) (*resty.Response, error)
@@ -92,4 +95,11 @@ type IFace interface {
 	InjectionBlock(input InjectionBlockInput) (*resty.Response, error)
 	Connections() (*resty.Response, Connections, error)
 	ActiveChains() (*resty.Response, ActiveChains, error)
+	MonitorHeads(input MonitorHeadsInput) (<-chan MonitoredBlock, <-chan error)
+	MonitorValidBlocks(input MonitorValidBlocksInput) (<-chan MonitoredBlock, <-chan error)
+	MonitorBootstrapped() (<-chan Bootstrapped, <-chan error)
+	MonitorProtocols() (<-chan string, <-chan error)
+	PendingOperations() (*resty.Response, PendingOperations, error)
+	MonitorOperations(input MonitorOperationsInput) (<-chan []Operations, <-chan error)
+	WaitForConfirmation(input WaitForConfirmationInput) (Confirmation, error)
 }
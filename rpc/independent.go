@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"time"
 
-	validator "github.com/go-playground/validator/v10"
 	"github.com/go-resty/resty/v2"
 	"github.com/pkg/errors"
 )
@@ -60,7 +59,7 @@ RPC:
 	https://tezos.gitlab.io/shell/rpc.html#post-injection-operation
 */
 func (c *Client) InjectionOperation(input InjectionOperationInput) (*resty.Response, string, error) {
-	err := validator.New().Struct(input)
+	err := validateInput(input)
 	if err != nil {
 		return nil, "", errors.Wrap(err, "failed to inject operation: invalid input")
 	}
@@ -145,7 +144,7 @@ RPC:
 	https://tezos.gitlab.io/shell/rpc.html#post-injection-block
 */
 func (c *Client) InjectionBlock(input InjectionBlockInput) (*resty.Response, error) {
-	err := validator.New().Struct(input)
+	err := validateInput(input)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to inject block: invalid input")
 	}
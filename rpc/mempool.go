@@ -0,0 +1,167 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/pkg/errors"
+)
+
+/*
+PendingOperations represents the operations in the mempool of a node. Refused, branch refused and branch delayed
+operations carry the reason in their Error field.
+
+RPC:
+	https://tezos.gitlab.io/shell/rpc.html#get-chains-chain-id-mempool-pending-operations
+*/
+type PendingOperations struct {
+	Applied       []Operations `json:"applied"`
+	Refused       []Operations `json:"refused"`
+	BranchRefused []Operations `json:"branch_refused"`
+	BranchDelayed []Operations `json:"branch_delayed"`
+	Unprocessed   []Operations `json:"unprocessed"`
+}
+
+// UnmarshalJSON satisfies json.Unmarshaler. Every class except applied is encoded as [hash, operation] pairs.
+func (p *PendingOperations) UnmarshalJSON(v []byte) error {
+	var pending struct {
+		Applied       []Operations        `json:"applied"`
+		Refused       [][]json.RawMessage `json:"refused"`
+		BranchRefused [][]json.RawMessage `json:"branch_refused"`
+		BranchDelayed [][]json.RawMessage `json:"branch_delayed"`
+		Unprocessed   [][]json.RawMessage `json:"unprocessed"`
+	}
+
+	if err := json.Unmarshal(v, &pending); err != nil {
+		return err
+	}
+
+	p.Applied = pending.Applied
+
+	var err error
+	if p.Refused, err = unmarshalHashedOperations(pending.Refused); err != nil {
+		return errors.Wrap(err, "failed to unmarshal refused operations")
+	}
+
+	if p.BranchRefused, err = unmarshalHashedOperations(pending.BranchRefused); err != nil {
+		return errors.Wrap(err, "failed to unmarshal branch refused operations")
+	}
+
+	if p.BranchDelayed, err = unmarshalHashedOperations(pending.BranchDelayed); err != nil {
+		return errors.Wrap(err, "failed to unmarshal branch delayed operations")
+	}
+
+	if p.Unprocessed, err = unmarshalHashedOperations(pending.Unprocessed); err != nil {
+		return errors.Wrap(err, "failed to unmarshal unprocessed operations")
+	}
+
+	return nil
+}
+
+func unmarshalHashedOperations(pairs [][]json.RawMessage) ([]Operations, error) {
+	if pairs == nil {
+		return nil, nil
+	}
+
+	operations := make([]Operations, len(pairs))
+	for i, pair := range pairs {
+		if len(pair) != 2 {
+			return nil, errors.Errorf("expected [hash, operation] pair but got %d elements", len(pair))
+		}
+
+		if err := json.Unmarshal(pair[1], &operations[i]); err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(pair[0], &operations[i].Hash); err != nil {
+			return nil, err
+		}
+	}
+
+	return operations, nil
+}
+
+/*
+PendingOperations lists the operations in the mempool of the client's chain, sorted by how the node classified
+them.
+
+Path:
+	../<chain_id>/mempool/pending_operations (GET)
+
+RPC:
+	https://tezos.gitlab.io/shell/rpc.html#get-chains-chain-id-mempool-pending-operations
+*/
+func (c *Client) PendingOperations() (*resty.Response, PendingOperations, error) {
+	resp, err := c.get(fmt.Sprintf("/chains/%s/mempool/pending_operations", c.chain))
+	if err != nil {
+		return resp, PendingOperations{}, errors.Wrap(err, "failed to get pending operations")
+	}
+
+	var pendingOperations PendingOperations
+	err = json.Unmarshal(resp.Body(), &pendingOperations)
+	if err != nil {
+		return resp, PendingOperations{}, errors.Wrap(err, "failed to get pending operations: failed to parse json")
+	}
+
+	return resp, pendingOperations, nil
+}
+
+/*
+MonitorOperationsInput is the input for the MonitorOperations function. The node only streams the classes that
+are set.
+
+Function:
+	func (c *Client) MonitorOperations(input MonitorOperationsInput) (<-chan []Operations, <-chan error)
+*/
+type MonitorOperationsInput struct {
+	Applied       bool
+	Refused       bool
+	BranchRefused bool
+	BranchDelayed bool
+}
+
+func (m *MonitorOperationsInput) query() url.Values {
+	query := url.Values{}
+	query.Set("applied", strconv.FormatBool(m.Applied))
+	query.Set("refused", strconv.FormatBool(m.Refused))
+	query.Set("branch_refused", strconv.FormatBool(m.BranchRefused))
+	query.Set("branch_delayed", strconv.FormatBool(m.BranchDelayed))
+	return query
+}
+
+/*
+MonitorOperations streams the operations of the client's chain as the mempool classifies them. Refused, branch
+refused and branch delayed operations carry the reason in their Error field.
+
+The node closes the stream whenever the head changes, after which it is reopened and the mempool is streamed
+again from the start, so the same operation may be received more than once. The stream follows the same
+reconnect and channel semantics as MonitorHeads.
+
+Path:
+	../<chain_id>/mempool/monitor_operations (GET)
+
+RPC:
+	https://tezos.gitlab.io/shell/rpc.html#get-chains-chain-id-mempool-monitor-operations
+*/
+func (c *Client) MonitorOperations(input MonitorOperationsInput) (<-chan []Operations, <-chan error) {
+	operations := make(chan []Operations)
+	errs := c.monitor(fmt.Sprintf("/chains/%s/mempool/monitor_operations", c.chain), input.query(), true, func(ctx context.Context, dec *json.Decoder) error {
+		var ops []Operations
+		if err := dec.Decode(&ops); err != nil {
+			return err
+		}
+
+		select {
+		case operations <- ops:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}, func() { close(operations) })
+
+	return operations, errs
+}
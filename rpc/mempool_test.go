@@ -0,0 +1,139 @@
+package rpc_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/goat-systems/go-tezos/v4/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PendingOperations(t *testing.T) {
+	type want struct {
+		wantErr     bool
+		containsErr string
+	}
+
+	cases := []struct {
+		name        string
+		inputHanler http.Handler
+		want
+	}{
+		{
+			"handles RPC error",
+			gtGoldenHTTPMock(mockHandler(&requestResultPair{regPendingOperations, readResponse(rpcerrors)}, blankHandler)),
+			want{
+				true,
+				"failed to get pending operations",
+			},
+		},
+		{
+			"handles failure to unmarshal",
+			gtGoldenHTTPMock(mockHandler(&requestResultPair{regPendingOperations, []byte(`junk`)}, blankHandler)),
+			want{
+				true,
+				"failed to get pending operations: failed to parse json",
+			},
+		},
+		{
+			"handles malformed operation pair",
+			gtGoldenHTTPMock(mockHandler(&requestResultPair{regPendingOperations, []byte(`{"applied":[],"refused":[["ooYSSxYcgreJQtrzxqfBdEG8Kbvb3AfBqJTvHKvXiryBbwPbbbx"]]}`)}, blankHandler)),
+			want{
+				true,
+				"failed to unmarshal refused operations",
+			},
+		},
+		{
+			"is successful",
+			gtGoldenHTTPMock(mockHandler(&requestResultPair{regPendingOperations, readResponse(pendingOperations)}, blankHandler)),
+			want{
+				false,
+				"",
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.inputHanler)
+			defer server.Close()
+
+			r, err := rpc.New(server.URL)
+			assert.Nil(t, err)
+
+			_, pending, err := r.PendingOperations()
+			checkErr(t, tt.wantErr, tt.containsErr, err)
+			if tt.wantErr {
+				assert.Equal(t, rpc.PendingOperations{}, pending)
+				return
+			}
+
+			assert.Len(t, pending.Applied, 1)
+			assert.Equal(t, "opPDN9AoGqfAJ8DGXYZ4WPGdbP8Jmoe5BtLZYFXCDN8WoaTBDYR", pending.Applied[0].Hash)
+			assert.Equal(t, "1000000", pending.Applied[0].Contents[0].Amount.String())
+			assert.Empty(t, pending.Applied[0].Error)
+
+			assert.Len(t, pending.Refused, 1)
+			assert.Equal(t, "ooYSSxYcgreJQtrzxqfBdEG8Kbvb3AfBqJTvHKvXiryBbwPbbbx", pending.Refused[0].Hash)
+			assert.Equal(t, "PtEdo2ZkT9oKpimTah6x2embF25oss54njMuPzkJTEi5RqfdZFA", pending.Refused[0].Protocol)
+			assert.Equal(t, "1022", pending.Refused[0].Contents[0].Counter)
+			assert.Len(t, pending.Refused[0].Error, 1)
+			assert.Equal(t, "temporary", pending.Refused[0].Error[0].Kind)
+			assert.Equal(t, "proto.008-PtEdo2Zk.contract.balance_too_low", pending.Refused[0].Error[0].ID)
+
+			assert.Empty(t, pending.BranchRefused)
+
+			assert.Len(t, pending.BranchDelayed, 1)
+			assert.Equal(t, "onvsLP3JFZia2mzZKWaFuFkWg2L5p3BDUhzh5Kr6CiDDN3rtQ1D", pending.BranchDelayed[0].Hash)
+			assert.Equal(t, "delegation", string(pending.BranchDelayed[0].Contents[0].Kind))
+			assert.Equal(t, "proto.008-PtEdo2Zk.contract.counter_in_the_future", pending.BranchDelayed[0].Error[0].ID)
+
+			assert.Empty(t, pending.Unprocessed)
+		})
+	}
+}
+
+func Test_MonitorOperations(t *testing.T) {
+	var query string
+	server := httptest.NewServer(gtGoldenHTTPMock(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/chains/main/mempool/monitor_operations" {
+			query = r.URL.RawQuery
+			fmt.Fprint(w, `[{"hash":"opPDN9AoGqfAJ8DGXYZ4WPGdbP8Jmoe5BtLZYFXCDN8WoaTBDYR","protocol":"PtEdo2ZkT9oKpimTah6x2embF25oss54njMuPzkJTEi5RqfdZFA","branch":"BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1","contents":[{"kind":"transaction","counter":"1021"}],"signature":"sigvs8WYSK3AgpWwpUXg8B9NyJjPcLYNqmZvNFR3UmtiiLfPTNZSEeU8qRs6LVTquyVUDdu4imEWTqD6sinURdJAmRoyffy9"}]`)
+			w.(http.Flusher).Flush()
+			fmt.Fprint(w, `[{"hash":"ooYSSxYcgreJQtrzxqfBdEG8Kbvb3AfBqJTvHKvXiryBbwPbbbx","protocol":"PtEdo2ZkT9oKpimTah6x2embF25oss54njMuPzkJTEi5RqfdZFA","branch":"BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1","contents":[{"kind":"transaction","counter":"1022"}],"signature":"sigvs8WYSK3AgpWwpUXg8B9NyJjPcLYNqmZvNFR3UmtiiLfPTNZSEeU8qRs6LVTquyVUDdu4imEWTqD6sinURdJAmRoyffy9","error":[{"kind":"temporary","id":"proto.008-PtEdo2Zk.contract.balance_too_low"}]}]`)
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		}
+	})))
+	defer server.Close()
+
+	r, err := rpc.New(server.URL)
+	checkErr(t, false, "", err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	operations, errs := r.WithContext(ctx).MonitorOperations(rpc.MonitorOperationsInput{
+		Applied: true,
+		Refused: true,
+	})
+
+	applied := <-operations
+	assert.Len(t, applied, 1)
+	assert.Equal(t, "opPDN9AoGqfAJ8DGXYZ4WPGdbP8Jmoe5BtLZYFXCDN8WoaTBDYR", applied[0].Hash)
+	assert.Empty(t, applied[0].Error)
+
+	refused := <-operations
+	assert.Len(t, refused, 1)
+	assert.Equal(t, "ooYSSxYcgreJQtrzxqfBdEG8Kbvb3AfBqJTvHKvXiryBbwPbbbx", refused[0].Hash)
+	assert.Equal(t, "proto.008-PtEdo2Zk.contract.balance_too_low", refused[0].Error[0].ID)
+
+	assert.Equal(t, "applied=true&branch_delayed=false&branch_refused=false&refused=true", query)
+
+	cancel()
+	_, ok := <-operations
+	assert.False(t, ok)
+	_, ok = <-errs
+	assert.False(t, ok)
+}
@@ -46,6 +46,7 @@ const (
 	operationhashes         responseKey = ".test-fixtures/operation_hashes.json"
 	operationMetaDataHashes responseKey = ".test-fixtures/operation_metadata_hashes.json"
 	parseOperations         responseKey = ".test-fixtures/parse_operations.json"
+	pendingOperations       responseKey = ".test-fixtures/pending_operations.json"
 	preapplyOperations      responseKey = ".test-fixtures/preapply_operations.json"
 	proposals               responseKey = ".test-fixtures/proposals.json"
 	protocols               responseKey = ".test-fixtures/protocols.json"
@@ -296,6 +297,7 @@ var (
 	regPackData                     = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/helpers/scripts/pack_data`)
 	regParseBlock                   = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/helpers\/parse\/block`)
 	regParseOperations              = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/helpers\/parse\/operations`)
+	regPendingOperations            = regexp.MustCompile(`\/chains\/main\/mempool\/pending_operations`)
 	regPreapplyBlock                = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/helpers\/preapply\/block`)
 	regPreapplyOperations           = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/helpers\/preapply\/operations`)
 	regProposals                    = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/votes\/proposals`)
@@ -409,3 +411,17 @@ func checkErr(t *testing.T, wantErr bool, errContains string, err error) {
 		assert.Nil(t, err)
 	}
 }
+
+// headBlockHandlerMock serves the block rpc.New resolves the head to before fetching constants.
+func headBlockHandlerMock(next http.Handler) http.Handler {
+	var used bool
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/chains/main/blocks/head" && !used {
+			w.Write(readResponse(block))
+			used = true
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
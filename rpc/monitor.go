@@ -0,0 +1,305 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	monitorMinBackoff = 100 * time.Millisecond
+	monitorMaxBackoff = 30 * time.Second
+)
+
+/*
+MonitoredBlock represents a block announced by the /monitor/heads and /monitor/valid_blocks streams.
+
+RPC:
+	https://tezos.gitlab.io/shell/rpc.html#get-monitor-heads-chain-id
+*/
+type MonitoredBlock struct {
+	ChainID string `json:"chain_id,omitempty"`
+	Hash    string `json:"hash"`
+	Header
+	ProtocolData string `json:"protocol_data,omitempty"`
+}
+
+/*
+Bootstrapped represents the progress reported by /monitor/bootstrapped.
+
+RPC:
+	https://tezos.gitlab.io/shell/rpc.html#get-monitor-bootstrapped
+*/
+type Bootstrapped struct {
+	Block     string    `json:"block"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+/*
+MonitorHeadsInput is the input for the MonitorHeads function.
+
+Function:
+	func (c *Client) MonitorHeads(input MonitorHeadsInput) (<-chan MonitoredBlock, <-chan error)
+*/
+type MonitorHeadsInput struct {
+	// The chain to monitor. Defaults to the client's chain.
+	Chain string
+	// Only stream heads whose next protocol is one of NextProtocols.
+	NextProtocols []string
+}
+
+/*
+MonitorHeads streams the new heads of a chain as they are validated.
+
+The stream reconnects with exponential backoff whenever the node closes it or the connection fails. Connection
+failures are sent on the error channel, which must be drained alongside the heads channel. Both channels are
+closed when the client's context is done (see WithContext).
+
+Path:
+	../monitor/heads/<chain_id> (GET)
+
+RPC:
+	https://tezos.gitlab.io/shell/rpc.html#get-monitor-heads-chain-id
+*/
+func (c *Client) MonitorHeads(input MonitorHeadsInput) (<-chan MonitoredBlock, <-chan error) {
+	chain := input.Chain
+	if chain == "" {
+		chain = c.chain
+	}
+
+	query := url.Values{}
+	for _, protocol := range input.NextProtocols {
+		query.Add("next_protocol", protocol)
+	}
+
+	heads := make(chan MonitoredBlock)
+	errs := c.monitor(fmt.Sprintf("/monitor/heads/%s", chain), query, true, func(ctx context.Context, dec *json.Decoder) error {
+		var head MonitoredBlock
+		if err := dec.Decode(&head); err != nil {
+			return err
+		}
+
+		select {
+		case heads <- head:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}, func() { close(heads) })
+
+	return heads, errs
+}
+
+/*
+MonitorValidBlocksInput is the input for the MonitorValidBlocks function.
+
+Function:
+	func (c *Client) MonitorValidBlocks(input MonitorValidBlocksInput) (<-chan MonitoredBlock, <-chan error)
+*/
+type MonitorValidBlocksInput struct {
+	// Only stream blocks of the given protocols.
+	Protocols []string
+	// Only stream blocks whose next protocol is one of NextProtocols.
+	NextProtocols []string
+	// Only stream blocks of the given chains.
+	Chains []string
+}
+
+/*
+MonitorValidBlocks streams all blocks that are successfully validated by the node, including those that never
+become the head. The stream follows the same reconnect and channel semantics as MonitorHeads.
+
+Path:
+	../monitor/valid_blocks (GET)
+
+RPC:
+	https://tezos.gitlab.io/shell/rpc.html#get-monitor-valid-blocks
+*/
+func (c *Client) MonitorValidBlocks(input MonitorValidBlocksInput) (<-chan MonitoredBlock, <-chan error) {
+	query := url.Values{}
+	for _, protocol := range input.Protocols {
+		query.Add("protocol", protocol)
+	}
+	for _, protocol := range input.NextProtocols {
+		query.Add("next_protocol", protocol)
+	}
+	for _, chain := range input.Chains {
+		query.Add("chain", chain)
+	}
+
+	blocks := make(chan MonitoredBlock)
+	errs := c.monitor("/monitor/valid_blocks", query, true, func(ctx context.Context, dec *json.Decoder) error {
+		var block MonitoredBlock
+		if err := dec.Decode(&block); err != nil {
+			return err
+		}
+
+		select {
+		case blocks <- block:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}, func() { close(blocks) })
+
+	return blocks, errs
+}
+
+/*
+MonitorBootstrapped streams the head of the node while it bootstraps. The node closes the stream once it is
+bootstrapped, after which both channels are closed. Connection failures before that are retried.
+
+Path:
+	../monitor/bootstrapped (GET)
+
+RPC:
+	https://tezos.gitlab.io/shell/rpc.html#get-monitor-bootstrapped
+*/
+func (c *Client) MonitorBootstrapped() (<-chan Bootstrapped, <-chan error) {
+	bootstrapped := make(chan Bootstrapped)
+	errs := c.monitor("/monitor/bootstrapped", url.Values{}, false, func(ctx context.Context, dec *json.Decoder) error {
+		var b Bootstrapped
+		if err := dec.Decode(&b); err != nil {
+			return err
+		}
+
+		select {
+		case bootstrapped <- b:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}, func() { close(bootstrapped) })
+
+	return bootstrapped, errs
+}
+
+/*
+MonitorProtocols streams the hashes of protocols as the node fetches or compiles them. The stream follows the
+same reconnect and channel semantics as MonitorHeads.
+
+Path:
+	../monitor/protocols (GET)
+
+RPC:
+	https://tezos.gitlab.io/shell/rpc.html#get-monitor-protocols
+*/
+func (c *Client) MonitorProtocols() (<-chan string, <-chan error) {
+	protocols := make(chan string)
+	errs := c.monitor("/monitor/protocols", url.Values{}, true, func(ctx context.Context, dec *json.Decoder) error {
+		var protocol string
+		if err := dec.Decode(&protocol); err != nil {
+			return err
+		}
+
+		select {
+		case protocols <- protocol:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}, func() { close(protocols) })
+
+	return protocols, errs
+}
+
+/*
+monitor opens a streaming RPC and hands the decoder to next until the stream ends. A stream that fails is
+reopened with exponential backoff capped at monitorMaxBackoff. A stream the node closes cleanly is only reopened
+if reconnect is set. done is called once the stream stops for good.
+*/
+func (c *Client) monitor(path string, query url.Values, reconnect bool, next func(ctx context.Context, dec *json.Decoder) error, done func()) <-chan error {
+	errs := make(chan error)
+	ctx := c.Context()
+
+	go func() {
+		defer close(errs)
+		defer done()
+
+		backoff := monitorMinBackoff
+		for {
+			received, err := c.stream(ctx, path, query, next)
+			if ctx.Err() != nil {
+				return
+			}
+
+			if err == nil && !reconnect {
+				return
+			}
+
+			if received {
+				backoff = monitorMinBackoff
+			}
+
+			if err != nil {
+				select {
+				case errs <- errors.Wrapf(err, "failed to monitor '%s'", path):
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return
+			}
+
+			backoff *= 2
+			if backoff > monitorMaxBackoff {
+				backoff = monitorMaxBackoff
+			}
+		}
+	}()
+
+	return errs
+}
+
+// stream reads a single connection to a streaming RPC. It reports whether any value was received and returns nil if the node closed the stream.
+func (c *Client) stream(ctx context.Context, path string, query url.Values, next func(ctx context.Context, dec *json.Decoder) error) (bool, error) {
+	host := c.hosts.host()
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetQueryParamsFromValues(query).
+		SetDoNotParseResponse(true).
+		Get(fmt.Sprintf("%s%s", host, path))
+	if err != nil {
+		// reconnect to another host if the client has several
+		if ctx.Err() == nil {
+			c.hosts.markDown(host)
+		}
+		return false, err
+	}
+
+	body := resp.RawBody()
+	defer body.Close()
+
+	if resp.StatusCode() != http.StatusOK {
+		v, _ := ioutil.ReadAll(body)
+		if err := handleRPCError(v); err != nil {
+			return false, err
+		}
+
+		return false, errors.Errorf("unexpected status '%s'", resp.Status())
+	}
+
+	var received bool
+	dec := json.NewDecoder(body)
+	for {
+		err := next(ctx, dec)
+		if err == io.EOF {
+			return received, nil
+		}
+		if err != nil {
+			return received, err
+		}
+		received = true
+	}
+}
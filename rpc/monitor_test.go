@@ -0,0 +1,175 @@
+package rpc_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/goat-systems/go-tezos/v4/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+// streamHandlerMock streams each chunk of a connection with a flush in between. Every request is served the next
+// connection in conns, and once they run out the request is held open until the client goes away.
+func streamHandlerMock(path string, conns [][]string, next http.Handler) http.Handler {
+	var (
+		mu    sync.Mutex
+		count int
+	)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		mu.Lock()
+		i := count
+		count++
+		mu.Unlock()
+
+		if i >= len(conns) {
+			<-r.Context().Done()
+			return
+		}
+
+		if len(conns[i]) == 1 && conns[i][0] == "error" {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write(readResponse(rpcerrors))
+			return
+		}
+
+		flusher := w.(http.Flusher)
+		for _, chunk := range conns[i] {
+			fmt.Fprint(w, chunk)
+			flusher.Flush()
+		}
+	})
+}
+
+func Test_MonitorHeads(t *testing.T) {
+	head := func(level int) string {
+		return fmt.Sprintf(`{"hash":"BL%d","level":%d,"proto":1,"predecessor":"BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1","timestamp":"2020-11-03T17:43:50Z","validation_pass":4,"operations_hash":"LLoa7bxRTKaQN2bLYoitYB6bU2DvLnBAqrVjZcvJ364cTcX2PZYKU","fitness":["01","0000000000035a72"],"context":"CoVDyf9y9gHfAkPWofBJffo4X4bWjmehH2LeVonDcCKKzyQYwqdk","protocol_data":"000000"}`+"\n", level, level)
+	}
+
+	server := httptest.NewServer(gtGoldenHTTPMock(streamHandlerMock("/monitor/heads/main", [][]string{
+		{head(1), head(2)},
+		{"error"},
+		{head(3)[:40], head(3)[40:]},
+	}, blankHandler)))
+	defer server.Close()
+
+	r, err := rpc.New(server.URL)
+	checkErr(t, false, "", err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	heads, errs := r.WithContext(ctx).MonitorHeads(rpc.MonitorHeadsInput{})
+
+	var levels []int
+	var hashes []string
+	for len(levels) < 3 {
+		select {
+		case h := <-heads:
+			levels = append(levels, h.Level)
+			hashes = append(hashes, h.Hash)
+			assert.Equal(t, "BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1", h.Predecessor)
+		case err := <-errs:
+			checkErr(t, true, "failed to monitor '/monitor/heads/main'", err)
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for heads")
+		}
+	}
+
+	assert.Equal(t, []int{1, 2, 3}, levels)
+	assert.Equal(t, []string{"BL1", "BL2", "BL3"}, hashes)
+
+	cancel()
+	for range heads {
+	}
+	for range errs {
+	}
+}
+
+func Test_MonitorBootstrapped(t *testing.T) {
+	server := httptest.NewServer(gtGoldenHTTPMock(streamHandlerMock("/monitor/bootstrapped", [][]string{
+		{
+			`{"block":"BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1","timestamp":"2020-11-03T17:43:50Z"}`,
+			`{"block":"BMLzHM9wBzMVjWAAjJ1XWyDz8Q3hrNALRpM7DSb2eMkprWX9e2j","timestamp":"2020-11-03T17:44:20Z"}`,
+		},
+	}, blankHandler)))
+	defer server.Close()
+
+	r, err := rpc.New(server.URL)
+	checkErr(t, false, "", err)
+
+	bootstrapped, errs := r.MonitorBootstrapped()
+
+	var blocks []string
+	for b := range bootstrapped {
+		blocks = append(blocks, b.Block)
+	}
+
+	_, ok := <-errs
+	assert.False(t, ok)
+	assert.Equal(t, []string{"BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1", "BMLzHM9wBzMVjWAAjJ1XWyDz8Q3hrNALRpM7DSb2eMkprWX9e2j"}, blocks)
+}
+
+func Test_MonitorValidBlocks(t *testing.T) {
+	var query string
+	server := httptest.NewServer(gtGoldenHTTPMock(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/monitor/valid_blocks" {
+			query = r.URL.RawQuery
+			fmt.Fprint(w, `{"chain_id":"NetXdQprcVkpaWU","hash":"BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1","level":10}`)
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		}
+	})))
+	defer server.Close()
+
+	r, err := rpc.New(server.URL)
+	checkErr(t, false, "", err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	blocks, errs := r.WithContext(ctx).MonitorValidBlocks(rpc.MonitorValidBlocksInput{
+		Protocols: []string{"PtEdo2ZkT9oKpimTah6x2embF25oss54njMuPzkJTEi5RqfdZFA"},
+		Chains:    []string{"main"},
+	})
+
+	b := <-blocks
+	assert.Equal(t, "NetXdQprcVkpaWU", b.ChainID)
+	assert.Equal(t, 10, b.Level)
+	assert.Equal(t, "chain=main&protocol=PtEdo2ZkT9oKpimTah6x2embF25oss54njMuPzkJTEi5RqfdZFA", query)
+
+	cancel()
+	_, ok := <-blocks
+	assert.False(t, ok)
+	_, ok = <-errs
+	assert.False(t, ok)
+}
+
+func Test_MonitorProtocols(t *testing.T) {
+	server := httptest.NewServer(gtGoldenHTTPMock(streamHandlerMock("/monitor/protocols", [][]string{
+		{`"PtEdo2ZkT9oKpimTah6x2embF25oss54njMuPzkJTEi5RqfdZFA"`},
+	}, blankHandler)))
+	defer server.Close()
+
+	r, err := rpc.New(server.URL)
+	checkErr(t, false, "", err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	protocols, errs := r.WithContext(ctx).MonitorProtocols()
+
+	assert.Equal(t, "PtEdo2ZkT9oKpimTah6x2embF25oss54njMuPzkJTEi5RqfdZFA", <-protocols)
+
+	cancel()
+	for range protocols {
+	}
+	for range errs {
+	}
+}
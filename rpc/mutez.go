@@ -0,0 +1,196 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+/*
+Mutez is an amount of tez in mutez, the smallest unit of tez (1 tez = 1000000 mutez). It is backed by a big.Int,
+so arithmetic never overflows, and it is encoded in JSON as a string of mutez like the node encodes amounts. The
+zero value is 0 mutez but is not set (see IsSet), so a required amount such as a fee that was never set fails
+validation while NewMutez(0) passes it. Mutez values are immutable: arithmetic returns a new value.
+
+Example:
+	amount, err := rpc.ParseTez("1.5")
+	if err != nil {
+		return err
+	}
+
+	total := amount.Add(rpc.NewMutez(1420)) // 1501420 mutez
+	fmt.Println(total.Tez())                 // 1.50142
+*/
+type Mutez struct {
+	v *big.Int
+}
+
+// NewMutez returns an amount of v mutez.
+func NewMutez(v int64) Mutez {
+	return mutez(big.NewInt(v))
+}
+
+// NewMutezFromBigInt returns an amount of v mutez.
+func NewMutezFromBigInt(v *big.Int) Mutez {
+	return mutez(new(big.Int).Set(v))
+}
+
+/*
+ParseMutez parses an amount in mutez, such as the amounts the node returns.
+
+Example:
+	fee, err := rpc.ParseMutez("1420")
+*/
+func ParseMutez(s string) (Mutez, error) {
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return Mutez{}, errors.Errorf("failed to parse mutez: invalid amount '%s'", s)
+	}
+
+	return mutez(v), nil
+}
+
+/*
+ParseTez parses an amount in tez with up to six decimals.
+
+Example:
+	amount, err := rpc.ParseTez("1.5") // 1500000 mutez
+*/
+func ParseTez(s string) (Mutez, error) {
+	whole, fraction := s, ""
+	if i := strings.IndexByte(s, '.'); i != -1 {
+		whole, fraction = s[:i], s[i+1:]
+	}
+
+	if len(fraction) > 6 || strings.ContainsAny(fraction, "+-") || (whole == "" && fraction == "") {
+		return Mutez{}, errors.Errorf("failed to parse tez: invalid amount '%s'", s)
+	}
+
+	v, ok := new(big.Int).SetString(whole+fraction+strings.Repeat("0", 6-len(fraction)), 10)
+	if !ok {
+		return Mutez{}, errors.Errorf("failed to parse tez: invalid amount '%s'", s)
+	}
+
+	return mutez(v), nil
+}
+
+// mutez normalises a computed zero to a fresh big.Int, so that zeros computed in different ways are deeply equal
+func mutez(v *big.Int) Mutez {
+	if v.Sign() == 0 {
+		return Mutez{v: new(big.Int)}
+	}
+
+	return Mutez{v: v}
+}
+
+func (m Mutez) big() *big.Int {
+	if m.v == nil {
+		return new(big.Int)
+	}
+
+	return m.v
+}
+
+// BigInt returns the amount in mutez as a big.Int.
+func (m Mutez) BigInt() *big.Int {
+	return new(big.Int).Set(m.big())
+}
+
+// Int64 returns the amount in mutez as an int64, and whether it fits in one.
+func (m Mutez) Int64() (int64, bool) {
+	return m.big().Int64(), m.big().IsInt64()
+}
+
+// Add returns m + n.
+func (m Mutez) Add(n Mutez) Mutez {
+	return mutez(new(big.Int).Add(m.big(), n.big()))
+}
+
+// Sub returns m - n.
+func (m Mutez) Sub(n Mutez) Mutez {
+	return mutez(new(big.Int).Sub(m.big(), n.big()))
+}
+
+// Mul returns m * n.
+func (m Mutez) Mul(n int64) Mutez {
+	return mutez(new(big.Int).Mul(m.big(), big.NewInt(n)))
+}
+
+// Div returns m / n rounded towards zero, and panics if n is zero.
+func (m Mutez) Div(n int64) Mutez {
+	return mutez(new(big.Int).Quo(m.big(), big.NewInt(n)))
+}
+
+// Cmp returns -1, 0 or +1 depending on whether m is less than, equal to or greater than n.
+func (m Mutez) Cmp(n Mutez) int {
+	return m.big().Cmp(n.big())
+}
+
+// Sign returns -1, 0 or +1 depending on whether m is negative, zero or positive.
+func (m Mutez) Sign() int {
+	return m.big().Sign()
+}
+
+// IsSet reports whether m was given a value, as opposed to the zero value Mutez{} (or null in JSON).
+func (m Mutez) IsSet() bool {
+	return m.v != nil
+}
+
+// IsZero reports whether m is 0 mutez.
+func (m Mutez) IsZero() bool {
+	return m.Sign() == 0
+}
+
+// String returns the amount in mutez.
+func (m Mutez) String() string {
+	return m.big().String()
+}
+
+// Tez returns the amount in tez, without trailing zeros (e.g. 1.5 for 1500000 mutez).
+func (m Mutez) Tez() string {
+	abs := new(big.Int).Abs(m.big())
+	whole, fraction := new(big.Int).QuoRem(abs, big.NewInt(MUTEZ), new(big.Int))
+
+	s := whole.String()
+	if fraction.Sign() != 0 {
+		digits := fraction.String()
+		s += "." + strings.TrimRight(strings.Repeat("0", 6-len(digits))+digits, "0")
+	}
+
+	if m.Sign() < 0 {
+		return "-" + s
+	}
+
+	return s
+}
+
+// MarshalJSON satisfies the json.Marshaler interface.
+func (m Mutez) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON satisfies the json.Unmarshaler interface. Amounts are accepted as strings or numbers.
+func (m *Mutez) UnmarshalJSON(v []byte) error {
+	v = bytes.TrimSpace(v)
+	if bytes.Equal(v, []byte("null")) {
+		return nil
+	}
+
+	s := string(v)
+	if len(v) > 0 && v[0] == '"' {
+		if err := json.Unmarshal(v, &s); err != nil {
+			return err
+		}
+	}
+
+	parsed, err := ParseMutez(s)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package rpc_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/goat-systems/go-tezos/v4/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseTez(t *testing.T) {
+	cases := []struct {
+		name        string
+		input       string
+		wantErr     bool
+		containsErr string
+		mutez       string
+		tez         string
+	}{
+		{"is successful with whole tez", "12", false, "", "12000000", "12"},
+		{"is successful with decimals", "1.5", false, "", "1500000", "1.5"},
+		{"is successful with mutez", "0.000001", false, "", "1", "0.000001"},
+		{"is successful with no whole part", ".25", false, "", "250000", "0.25"},
+		{"is successful with a negative amount", "-3.1", false, "", "-3100000", "-3.1"},
+		{"is successful beyond int64", "18446744073709.551616", false, "", "18446744073709551616", "18446744073709.551616"},
+		{"handles too many decimals", "1.0000001", true, "failed to parse tez: invalid amount '1.0000001'", "0", "0"},
+		{"handles a signed fraction", "1.-5", true, "failed to parse tez", "0", "0"},
+		{"handles garbage", "one", true, "failed to parse tez", "0", "0"},
+		{"handles an empty amount", "", true, "failed to parse tez", "0", "0"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := rpc.ParseTez(tt.input)
+			checkErr(t, tt.wantErr, tt.containsErr, err)
+			assert.Equal(t, tt.mutez, m.String())
+			assert.Equal(t, tt.tez, m.Tez())
+		})
+	}
+}
+
+func Test_ParseMutez(t *testing.T) {
+	m, err := rpc.ParseMutez("1420")
+	checkErr(t, false, "", err)
+	assert.Equal(t, rpc.NewMutez(1420), m)
+	assert.Equal(t, "0.00142", m.Tez())
+
+	_, err = rpc.ParseMutez("1.5")
+	checkErr(t, true, "failed to parse mutez: invalid amount '1.5'", err)
+}
+
+func Test_Mutez_Arithmetic(t *testing.T) {
+	a := rpc.NewMutez(1500000)
+	b := rpc.NewMutez(1420)
+
+	assert.Equal(t, rpc.NewMutez(1501420), a.Add(b))
+	assert.Equal(t, rpc.NewMutez(1498580), a.Sub(b))
+	assert.Equal(t, rpc.NewMutez(-1498580), b.Sub(a))
+	assert.Equal(t, rpc.NewMutez(4500000), a.Mul(3))
+	assert.Equal(t, rpc.NewMutez(473), b.Div(3))
+	assert.Equal(t, 1, a.Cmp(b))
+	assert.Equal(t, -1, b.Cmp(a))
+	assert.Equal(t, 0, a.Cmp(rpc.NewMutez(1500000)))
+	assert.Equal(t, rpc.NewMutez(0), a.Sub(a))
+	assert.True(t, a.Sub(a).IsZero())
+	assert.Equal(t, -1, b.Sub(a).Sign())
+
+	max := rpc.NewMutez(9223372036854775807)
+	v, ok := max.Int64()
+	assert.True(t, ok)
+	assert.Equal(t, int64(9223372036854775807), v)
+
+	_, ok = max.Add(rpc.NewMutez(1)).Int64()
+	assert.False(t, ok)
+	assert.Equal(t, "9223372036854775808", max.Add(rpc.NewMutez(1)).String())
+
+	// arithmetic never modifies its operands
+	assert.Equal(t, "1500000", a.String())
+	assert.Equal(t, "1420", b.String())
+}
+
+func Test_Mutez_JSON(t *testing.T) {
+	type amounts struct {
+		Fee     rpc.Mutez `json:"fee"`
+		Amount  rpc.Mutez `json:"amount"`
+		Balance rpc.Mutez `json:"balance"`
+	}
+
+	cases := []struct {
+		name        string
+		input       string
+		wantErr     bool
+		containsErr string
+		want        amounts
+		output      string
+	}{
+		{
+			"is successful with strings",
+			`{"fee":"1420","amount":"18446744073709551616","balance":"0"}`,
+			false,
+			"",
+			amounts{Fee: rpc.NewMutez(1420), Amount: rpc.NewMutez(9223372036854775807).Mul(2).Add(rpc.NewMutez(2)), Balance: rpc.NewMutez(0)},
+			`{"fee":"1420","amount":"18446744073709551616","balance":"0"}`,
+		},
+		{
+			"is successful with numbers and null",
+			`{"fee":1420,"amount":null}`,
+			false,
+			"",
+			amounts{Fee: rpc.NewMutez(1420)},
+			`{"fee":"1420","amount":"0","balance":"0"}`,
+		},
+		{
+			"handles an invalid amount",
+			`{"fee":"1.5"}`,
+			true,
+			"failed to parse mutez: invalid amount '1.5'",
+			amounts{},
+			`{"fee":"0","amount":"0","balance":"0"}`,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var a amounts
+			err := json.Unmarshal([]byte(tt.input), &a)
+			checkErr(t, tt.wantErr, tt.containsErr, err)
+			assert.Equal(t, tt.want, a)
+
+			v, err := json.Marshal(a)
+			checkErr(t, false, "", err)
+			assert.Equal(t, tt.output, string(v))
+		})
+	}
+}
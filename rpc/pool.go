@@ -0,0 +1,265 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/pkg/errors"
+)
+
+// hostDownTime is how long a host that failed is skipped while other hosts are healthy
+const hostDownTime = 30 * time.Second
+
+/*
+RetryPolicy configures how the client retries requests that fail with a connection error or a 429, 502, 503 or
+504 status. Each retry goes to the next healthy host when the client has several (see NewWithHosts). Errors
+returned by the node itself, such as a failed operation, are never retried.
+*/
+type RetryPolicy struct {
+	// The number of times a failed request is retried. Zero disables retries.
+	MaxRetries int
+	// The delay before the first retry, doubled on every retry up to MaxBackoff. A random jitter of up to half the delay is subtracted so clients don't retry in lockstep.
+	InitialBackoff time.Duration
+	// The maximum delay between two retries. Defaults to InitialBackoff if smaller.
+	MaxBackoff time.Duration
+	// Retry POST requests too, which include injections. Only GET requests, which are idempotent, are retried by default.
+	RetryPOST bool
+}
+
+// DefaultRetryPolicy retries GET requests three times with exponential backoff starting at 100ms.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries:     3,
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     2 * time.Second,
+}
+
+/*
+SetRetryPolicy sets how the client retries failed requests.
+
+Example:
+	client.SetRetryPolicy(rpc.RetryPolicy{
+		MaxRetries:     5,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+	})
+*/
+func (c *Client) SetRetryPolicy(policy RetryPolicy) {
+	c.retryPolicy = policy
+}
+
+/*
+SetInjectionHosts routes operation and block injections to hosts, which fail over between each other the same way
+the client's hosts do, while every other request keeps going to the client's hosts. This allows reading from public
+nodes and injecting through a trusted one. It may be called at any time, including while requests and a health
+check (see StartHealthCheck) are running.
+
+Example:
+	client.SetInjectionHosts("http://127.0.0.1:8732")
+*/
+func (c *Client) SetInjectionHosts(hosts ...string) {
+	var pool *hostPool
+	if len(hosts) > 0 {
+		pool = newHostPool(hosts...)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.injectionHosts = pool
+}
+
+// injectionPool returns the hosts injections go to, which is nil if they go to the client's hosts
+func (c *Client) injectionPool() *hostPool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.injectionHosts
+}
+
+/*
+StartHealthCheck checks the health of every host of the client, including injection hosts, every interval until ctx
+is done. A host that fails the check is skipped until it passes again, and a host that was skipped after a failed
+request is used again as soon as it passes.
+
+Path:
+	../chains/<chain_id>/blocks/head/hash (GET)
+
+Example:
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client.StartHealthCheck(ctx, 10*time.Second)
+*/
+func (c *Client) StartHealthCheck(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			for _, pool := range []*hostPool{c.hosts, c.injectionPool()} {
+				if pool == nil {
+					continue
+				}
+
+				for _, host := range pool.hosts {
+					resp, err := c.client.R().
+						SetContext(ctx).
+						Get(fmt.Sprintf("%s/chains/%s/blocks/head/hash", host, c.chain))
+					if ctx.Err() != nil {
+						return
+					}
+
+					if err != nil || resp.StatusCode() != http.StatusOK {
+						pool.markDown(host)
+					} else {
+						pool.markUp(host)
+					}
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// do sends a request to the first healthy host for path, and retries it according to the client's retry policy
+func (c *Client) do(method, path string, body interface{}, opts ...rpcOptions) (*resty.Response, error) {
+	pool := c.hosts
+	if injectionHosts := c.injectionPool(); injectionHosts != nil && strings.HasPrefix(path, "/injection/") {
+		pool = injectionHosts
+	}
+
+	retries := 0
+	if method == resty.MethodGet || c.retryPolicy.RetryPOST {
+		retries = c.retryPolicy.MaxRetries
+	}
+
+	ctx := c.Context()
+	backoff := c.retryPolicy.InitialBackoff
+	for attempt := 0; ; attempt++ {
+		host := pool.host()
+		req := c.client.R().
+			SetContext(ctx).
+			SetQueryParams(queryParams(opts...))
+		if method == resty.MethodPost {
+			req.SetHeader("Content-Type", "application/json").SetBody(body)
+		}
+
+		resp, err := req.Execute(method, fmt.Sprintf("%s%s", host, path))
+		if !retryable(resp, err) || ctx.Err() != nil {
+			if err != nil {
+				return resp, err
+			}
+
+			pool.markUp(host)
+			return resp, handleRPCError(resp.Body())
+		}
+
+		pool.markDown(host)
+		if attempt >= retries {
+			if err != nil {
+				return resp, err
+			}
+			return resp, errors.Errorf("unexpected status '%s'", resp.Status())
+		}
+
+		if backoff > 0 {
+			select {
+			case <-time.After(backoff - time.Duration(rand.Int63n(int64(backoff)/2+1))):
+			case <-ctx.Done():
+				return resp, ctx.Err()
+			}
+
+			backoff *= 2
+			if backoff > c.retryPolicy.MaxBackoff {
+				backoff = c.retryPolicy.MaxBackoff
+			}
+			if backoff < c.retryPolicy.InitialBackoff {
+				backoff = c.retryPolicy.InitialBackoff
+			}
+		}
+	}
+}
+
+// retryable reports whether a request failed in a way another attempt could fix
+func retryable(resp *resty.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	switch resp.StatusCode() {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// hostPool keeps the hosts of a client in order of preference along with the ones that recently failed
+type hostPool struct {
+	hosts []string
+	mu    sync.Mutex
+	down  map[string]time.Time
+}
+
+func newHostPool(hosts ...string) *hostPool {
+	p := &hostPool{down: make(map[string]time.Time)}
+	for _, host := range hosts {
+		p.hosts = append(p.hosts, cleanseHost(host))
+	}
+
+	return p
+}
+
+// host returns the first healthy host, or the host that has been down the longest if none are
+func (p *hostPool) host() string {
+	if p == nil || len(p.hosts) == 0 {
+		return ""
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	best := p.hosts[0]
+	for _, host := range p.hosts {
+		until, ok := p.down[host]
+		if !ok || now.After(until) {
+			return host
+		}
+
+		if until.Before(p.down[best]) {
+			best = host
+		}
+	}
+
+	return best
+}
+
+func (p *hostPool) markDown(host string) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.down[host] = time.Now().Add(hostDownTime)
+}
+
+func (p *hostPool) markUp(host string) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.down, host)
+}
@@ -0,0 +1,93 @@
+package rpc
+
+import (
+	"fmt"
+	"reflect"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/goat-systems/go-tezos/v4/tezos"
+	"github.com/pkg/errors"
+)
+
+// parsers are the validation tags of inputs backed by the tezos package, along with the parser of each
+var parsers = map[string]func(s string) error{
+	"address": func(s string) error {
+		_, err := tezos.ParseAddress(s)
+		return err
+	},
+	"key_hash": func(s string) error {
+		_, err := tezos.ParseKeyHash(s)
+		return err
+	},
+	"block_hash": func(s string) error {
+		_, err := tezos.ParseBlockHash(s)
+		return err
+	},
+	"operation_hash": func(s string) error {
+		_, err := tezos.ParseOperationHash(s)
+		return err
+	},
+	"chain_id": func(s string) error {
+		_, err := tezos.ParseChainID(s)
+		return err
+	},
+}
+
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+	RegisterValidations(v)
+
+	return v
+}
+
+/*
+RegisterValidations registers the validations of the inputs of this package with v: Mutez fields are validated by
+their value, which is empty if they were never set so that required rejects them, and the address, key_hash,
+block_hash, operation_hash and chain_id tags are validated by the tezos package. The forge package validates
+operation contents with them.
+
+Example:
+	validate := validator.New()
+	rpc.RegisterValidations(validate)
+*/
+func RegisterValidations(v *validator.Validate) {
+	v.RegisterCustomTypeFunc(mutezValue, Mutez{})
+	for tag, parse := range parsers {
+		parse := parse
+		v.RegisterValidation(tag, func(fl validator.FieldLevel) bool {
+			return parse(fl.Field().String()) == nil
+		})
+	}
+}
+
+/*
+validateInput validates the fields of an input against their validate tags. A field that fails one of the tags
+backed by the tezos package returns the parser's error, which says what is wrong with the value.
+*/
+func validateInput(input interface{}) error {
+	err := validate.Struct(input)
+
+	var fieldErrs validator.ValidationErrors
+	if errors.As(err, &fieldErrs) {
+		for _, fieldErr := range fieldErrs {
+			if parse, ok := parsers[fieldErr.Tag()]; ok {
+				if perr := parse(fmt.Sprint(fieldErr.Value())); perr != nil {
+					return errors.Wrapf(perr, "invalid %s", fieldErr.Field())
+				}
+			}
+		}
+	}
+
+	return err
+}
+
+// mutezValue is the value a Mutez field is validated by, which is empty if it is not set so that required fails
+func mutezValue(field reflect.Value) interface{} {
+	if m, ok := field.Interface().(Mutez); ok && m.IsSet() {
+		return m.String()
+	}
+
+	return ""
+}
@@ -0,0 +1,185 @@
+package tezos
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// maxEntrypointLength is the maximum length of an entrypoint name
+const maxEntrypointLength = 31
+
+// KeyHash is the hash of a public key, which is the address of an implicit account (tz1, tz2 or tz3).
+type KeyHash struct {
+	curve ECKind
+	hash  [20]byte
+}
+
+/*
+ParseKeyHash parses and validates a key hash (tz1, tz2 or tz3).
+
+Example:
+	delegate, err := tezos.ParseKeyHash("tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc")
+*/
+func ParseKeyHash(s string) (KeyHash, error) {
+	v, e, err := decode("key hash", s, tz1, tz2, tz3)
+	if err != nil {
+		return KeyHash{}, err
+	}
+
+	k := KeyHash{curve: e.curve}
+	copy(k.hash[:], v)
+	return k, nil
+}
+
+// Curve returns the curve of the key the hash is of.
+func (k KeyHash) Curve() ECKind {
+	return k.curve
+}
+
+// Bytes returns the 20 bytes of the hash.
+func (k KeyHash) Bytes() []byte {
+	return append([]byte{}, k.hash[:]...)
+}
+
+// String returns the base58 encoded key hash.
+func (k KeyHash) String() string {
+	return encode(k.hash[:], keyHashEncoding(k.curve))
+}
+
+// MarshalText satisfies the encoding.TextMarshaler interface.
+func (k KeyHash) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// UnmarshalText satisfies the encoding.TextUnmarshaler interface.
+func (k *KeyHash) UnmarshalText(v []byte) error {
+	parsed, err := ParseKeyHash(string(v))
+	if err != nil {
+		return err
+	}
+	*k = parsed
+
+	return nil
+}
+
+func keyHashEncoding(curve ECKind) encoding {
+	switch curve {
+	case Secp256k1:
+		return tz2
+	case NistP256:
+		return tz3
+	}
+
+	return tz1
+}
+
+/*
+Address is the address of an implicit account (tz1, tz2 or tz3) or an originated contract (KT1), optionally
+followed by an entrypoint (e.g. KT1DrJV8vhkdLEj76h1H9Q4irZDqAkMPo1Qf%transfer).
+*/
+type Address struct {
+	curve      ECKind
+	hash       [20]byte
+	originated bool
+	entrypoint string
+}
+
+/*
+ParseAddress parses and validates an address, with or without an entrypoint.
+
+Example:
+	address, err := tezos.ParseAddress("KT1DrJV8vhkdLEj76h1H9Q4irZDqAkMPo1Qf%transfer")
+*/
+func ParseAddress(s string) (Address, error) {
+	address, entrypoint := s, ""
+	if i := strings.IndexByte(s, '%'); i != -1 {
+		address, entrypoint = s[:i], s[i+1:]
+		if entrypoint == "" {
+			return Address{}, errors.Errorf("invalid address '%s': empty entrypoint", s)
+		}
+
+		if len(entrypoint) > maxEntrypointLength {
+			return Address{}, errors.Errorf("invalid address '%s': entrypoint is longer than %d characters", s, maxEntrypointLength)
+		}
+	}
+
+	v, e, err := decode("address", address, tz1, tz2, tz3, kt1)
+	if err != nil {
+		return Address{}, err
+	}
+
+	a := Address{curve: e.curve, originated: e.name == kt1.name, entrypoint: entrypoint}
+	copy(a.hash[:], v)
+	return a, nil
+}
+
+// IsImplicit reports whether the address is the address of an implicit account (tz1, tz2 or tz3).
+func (a Address) IsImplicit() bool {
+	return a.curve != ""
+}
+
+// IsOriginated reports whether the address is the address of an originated contract (KT1).
+func (a Address) IsOriginated() bool {
+	return a.originated
+}
+
+// Curve returns the curve of the key of an implicit account, or an empty kind for an originated contract.
+func (a Address) Curve() ECKind {
+	return a.curve
+}
+
+// KeyHash returns the key hash of an implicit account, and false for an originated contract.
+func (a Address) KeyHash() (KeyHash, bool) {
+	if !a.IsImplicit() {
+		return KeyHash{}, false
+	}
+
+	return KeyHash{curve: a.curve, hash: a.hash}, true
+}
+
+// Hash returns the 20 bytes of the hash of the address.
+func (a Address) Hash() []byte {
+	return append([]byte{}, a.hash[:]...)
+}
+
+// Entrypoint returns the entrypoint of the address, or an empty string if it has none.
+func (a Address) Entrypoint() string {
+	return a.entrypoint
+}
+
+// WithoutEntrypoint returns the address without its entrypoint.
+func (a Address) WithoutEntrypoint() Address {
+	a.entrypoint = ""
+	return a
+}
+
+// String returns the base58 encoded address, followed by its entrypoint if it has one.
+func (a Address) String() string {
+	e := kt1
+	if !a.originated {
+		e = keyHashEncoding(a.curve)
+	}
+
+	if a.entrypoint != "" {
+		return encode(a.hash[:], e) + "%" + a.entrypoint
+	}
+
+	return encode(a.hash[:], e)
+}
+
+// MarshalText satisfies the encoding.TextMarshaler interface.
+func (a Address) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText satisfies the encoding.TextUnmarshaler interface.
+func (a *Address) UnmarshalText(v []byte) error {
+	parsed, err := ParseAddress(string(v))
+	if err != nil {
+		return err
+	}
+	*a = parsed
+
+	return nil
+}
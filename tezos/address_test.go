@@ -0,0 +1,96 @@
+package tezos
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/goat-systems/go-tezos/v4/internal/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseAddress(t *testing.T) {
+	type want struct {
+		err         bool
+		errContains string
+		curve       ECKind
+		originated  bool
+		entrypoint  string
+	}
+
+	cases := []struct {
+		name  string
+		input string
+		want  want
+	}{
+		{"is successful with tz1", "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc", want{false, "", Ed25519, false, ""}},
+		{"is successful with tz2", "tz2L2HuhaaSnf6ShEDdhTEAr5jGPWPNwpvcB", want{false, "", Secp256k1, false, ""}},
+		{"is successful with tz3", "tz3fU9apdFnzoPhi4LB8AdxoiSVwLYM4kQ1F", want{false, "", NistP256, false, ""}},
+		{"is successful with KT1", "KT1DrJV8vhkdLEj76h1H9Q4irZDqAkMPo1Qf", want{false, "", "", true, ""}},
+		{"is successful with an entrypoint", "KT1DrJV8vhkdLEj76h1H9Q4irZDqAkMPo1Qf%transfer", want{false, "", "", true, "transfer"}},
+		{"handles an empty entrypoint", "KT1DrJV8vhkdLEj76h1H9Q4irZDqAkMPo1Qf%", want{true, "invalid address 'KT1DrJV8vhkdLEj76h1H9Q4irZDqAkMPo1Qf%': empty entrypoint", "", false, ""}},
+		{"handles a long entrypoint", "KT1DrJV8vhkdLEj76h1H9Q4irZDqAkMPo1Qf%abcdefghijklmnopqrstuvwxyz012345", want{true, "entrypoint is longer than 31 characters", "", false, ""}},
+		{"handles an invalid checksum", "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yd", want{true, "invalid address 'tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yd': data and checksum don't match", "", false, ""}},
+		{"handles an invalid character", "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Y0", want{true, "character not found in alphabet", "", false, ""}},
+		{"handles another kind of value", "BLz6yCE4BUL4ppo1zsEWdK9FRCt15WAY7ECQcuK9RtWg4xeEVL7", want{true, "unknown prefix, expected tz1, tz2, tz3, KT1", "", false, ""}},
+		{"handles an empty address", "", want{true, "invalid address ''", "", false, ""}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			address, err := ParseAddress(tt.input)
+			testutils.CheckErr(t, tt.want.err, tt.want.errContains, err)
+			if tt.want.err {
+				return
+			}
+
+			assert.Equal(t, tt.input, address.String())
+			assert.Equal(t, tt.want.curve, address.Curve())
+			assert.Equal(t, tt.want.originated, address.IsOriginated())
+			assert.Equal(t, !tt.want.originated, address.IsImplicit())
+			assert.Equal(t, tt.want.entrypoint, address.Entrypoint())
+			assert.Len(t, address.Hash(), 20)
+
+			keyHash, ok := address.KeyHash()
+			assert.Equal(t, !tt.want.originated, ok)
+			if ok {
+				assert.Equal(t, tt.input, keyHash.String())
+			}
+		})
+	}
+}
+
+func Test_ParseKeyHash(t *testing.T) {
+	keyHash, err := ParseKeyHash("tz2L2HuhaaSnf6ShEDdhTEAr5jGPWPNwpvcB")
+	testutils.CheckErr(t, false, "", err)
+	assert.Equal(t, Secp256k1, keyHash.Curve())
+	assert.Equal(t, "tz2L2HuhaaSnf6ShEDdhTEAr5jGPWPNwpvcB", keyHash.String())
+
+	_, err = ParseKeyHash("KT1DrJV8vhkdLEj76h1H9Q4irZDqAkMPo1Qf")
+	testutils.CheckErr(t, true, "invalid key hash 'KT1DrJV8vhkdLEj76h1H9Q4irZDqAkMPo1Qf': unknown prefix, expected tz1, tz2, tz3", err)
+}
+
+func Test_Address_WithoutEntrypoint(t *testing.T) {
+	address, err := ParseAddress("KT1DrJV8vhkdLEj76h1H9Q4irZDqAkMPo1Qf%transfer")
+	testutils.CheckErr(t, false, "", err)
+	assert.Equal(t, "KT1DrJV8vhkdLEj76h1H9Q4irZDqAkMPo1Qf", address.WithoutEntrypoint().String())
+	assert.Equal(t, "KT1DrJV8vhkdLEj76h1H9Q4irZDqAkMPo1Qf%transfer", address.String())
+}
+
+func Test_Address_JSON(t *testing.T) {
+	var v struct {
+		Destination Address `json:"destination"`
+		Delegate    KeyHash `json:"delegate"`
+	}
+
+	input := `{"destination":"KT1DrJV8vhkdLEj76h1H9Q4irZDqAkMPo1Qf%transfer","delegate":"tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc"}`
+	err := json.Unmarshal([]byte(input), &v)
+	testutils.CheckErr(t, false, "", err)
+	assert.Equal(t, "transfer", v.Destination.Entrypoint())
+
+	out, err := json.Marshal(v)
+	testutils.CheckErr(t, false, "", err)
+	assert.Equal(t, input, string(out))
+
+	err = json.Unmarshal([]byte(`{"delegate":"KT1DrJV8vhkdLEj76h1H9Q4irZDqAkMPo1Qf"}`), &v)
+	testutils.CheckErr(t, true, "invalid key hash", err)
+}
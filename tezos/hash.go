@@ -0,0 +1,149 @@
+package tezos
+
+// BlockHash is the hash of a block (B).
+type BlockHash [32]byte
+
+/*
+ParseBlockHash parses and validates a block hash.
+
+Example:
+	hash, err := tezos.ParseBlockHash("BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1")
+*/
+func ParseBlockHash(s string) (BlockHash, error) {
+	var h BlockHash
+	v, _, err := decode("block hash", s, blockHash)
+	copy(h[:], v)
+	return h, err
+}
+
+// String returns the base58 encoded hash.
+func (h BlockHash) String() string {
+	return encode(h[:], blockHash)
+}
+
+// MarshalText satisfies the encoding.TextMarshaler interface.
+func (h BlockHash) MarshalText() ([]byte, error) {
+	return []byte(h.String()), nil
+}
+
+// UnmarshalText satisfies the encoding.TextUnmarshaler interface.
+func (h *BlockHash) UnmarshalText(v []byte) error {
+	parsed, err := ParseBlockHash(string(v))
+	if err != nil {
+		return err
+	}
+	*h = parsed
+
+	return nil
+}
+
+// OperationHash is the hash of an operation (o).
+type OperationHash [32]byte
+
+/*
+ParseOperationHash parses and validates an operation hash.
+
+Example:
+	hash, err := tezos.ParseOperationHash("oozWCsudcyv9vdp8xzpBNLeaogU6fNHg4ikKYonJYhJiC7jw1W7")
+*/
+func ParseOperationHash(s string) (OperationHash, error) {
+	var h OperationHash
+	v, _, err := decode("operation hash", s, operationHash)
+	copy(h[:], v)
+	return h, err
+}
+
+// String returns the base58 encoded hash.
+func (h OperationHash) String() string {
+	return encode(h[:], operationHash)
+}
+
+// MarshalText satisfies the encoding.TextMarshaler interface.
+func (h OperationHash) MarshalText() ([]byte, error) {
+	return []byte(h.String()), nil
+}
+
+// UnmarshalText satisfies the encoding.TextUnmarshaler interface.
+func (h *OperationHash) UnmarshalText(v []byte) error {
+	parsed, err := ParseOperationHash(string(v))
+	if err != nil {
+		return err
+	}
+	*h = parsed
+
+	return nil
+}
+
+// ProtocolHash is the hash of a protocol (P).
+type ProtocolHash [32]byte
+
+/*
+ParseProtocolHash parses and validates a protocol hash.
+
+Example:
+	hash, err := tezos.ParseProtocolHash("PtEdo2ZkT9oKpimTah6x2embF25oss54njMuPzkJTEi5RqfdZFA")
+*/
+func ParseProtocolHash(s string) (ProtocolHash, error) {
+	var h ProtocolHash
+	v, _, err := decode("protocol hash", s, protocolHash)
+	copy(h[:], v)
+	return h, err
+}
+
+// String returns the base58 encoded hash.
+func (h ProtocolHash) String() string {
+	return encode(h[:], protocolHash)
+}
+
+// MarshalText satisfies the encoding.TextMarshaler interface.
+func (h ProtocolHash) MarshalText() ([]byte, error) {
+	return []byte(h.String()), nil
+}
+
+// UnmarshalText satisfies the encoding.TextUnmarshaler interface.
+func (h *ProtocolHash) UnmarshalText(v []byte) error {
+	parsed, err := ParseProtocolHash(string(v))
+	if err != nil {
+		return err
+	}
+	*h = parsed
+
+	return nil
+}
+
+// ChainID is the ID of a chain (Net).
+type ChainID [4]byte
+
+/*
+ParseChainID parses and validates a chain ID.
+
+Example:
+	id, err := tezos.ParseChainID("NetXdQprcVkpaWU")
+*/
+func ParseChainID(s string) (ChainID, error) {
+	var id ChainID
+	v, _, err := decode("chain id", s, chainID)
+	copy(id[:], v)
+	return id, err
+}
+
+// String returns the base58 encoded chain ID.
+func (id ChainID) String() string {
+	return encode(id[:], chainID)
+}
+
+// MarshalText satisfies the encoding.TextMarshaler interface.
+func (id ChainID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText satisfies the encoding.TextUnmarshaler interface.
+func (id *ChainID) UnmarshalText(v []byte) error {
+	parsed, err := ParseChainID(string(v))
+	if err != nil {
+		return err
+	}
+	*id = parsed
+
+	return nil
+}
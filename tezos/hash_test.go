@@ -0,0 +1,57 @@
+package tezos
+
+import (
+	"testing"
+
+	"github.com/goat-systems/go-tezos/v4/internal/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseHashes(t *testing.T) {
+	cases := []struct {
+		name        string
+		input       string
+		parse       func(s string) (string, error)
+		wantErr     bool
+		containsErr string
+	}{
+		{"is successful with a block hash", "BLz6yCE4BUL4ppo1zsEWdK9FRCt15WAY7ECQcuK9RtWg4xeEVL7", parseBlockHash, false, ""},
+		{"is successful with an operation hash", "oozWCsudcyv9vdp8xzpBNLeaogU6fNHg4ikKYonJYhJiC7jw1W7", parseOperationHash, false, ""},
+		{"is successful with a protocol hash", "PtEdo2ZkT9oKpimTah6x2embF25oss54njMuPzkJTEi5RqfdZFA", parseProtocolHash, false, ""},
+		{"is successful with a chain id", "NetXdQprcVkpaWU", parseChainID, false, ""},
+		{"handles an operation hash as a block hash", "oozWCsudcyv9vdp8xzpBNLeaogU6fNHg4ikKYonJYhJiC7jw1W7", parseBlockHash, true, "invalid block hash 'oozWCsudcyv9vdp8xzpBNLeaogU6fNHg4ikKYonJYhJiC7jw1W7': unknown prefix, expected B"},
+		{"handles a block hash as an operation hash", "BLz6yCE4BUL4ppo1zsEWdK9FRCt15WAY7ECQcuK9RtWg4xeEVL7", parseOperationHash, true, "invalid operation hash 'BLz6yCE4BUL4ppo1zsEWdK9FRCt15WAY7ECQcuK9RtWg4xeEVL7': unknown prefix, expected o"},
+		{"handles an invalid protocol hash", "PtEdo2ZkT9oKpimTah6x2embF25oss54njMuPzkJTEi5RqfdZFB", parseProtocolHash, true, "invalid protocol hash 'PtEdo2ZkT9oKpimTah6x2embF25oss54njMuPzkJTEi5RqfdZFB': data and checksum don't match"},
+		{"handles an invalid chain id", "some_chain_id", parseChainID, true, "invalid chain id 'some_chain_id': character not found in alphabet"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := tt.parse(tt.input)
+			testutils.CheckErr(t, tt.wantErr, tt.containsErr, err)
+			if !tt.wantErr {
+				assert.Equal(t, tt.input, s)
+			}
+		})
+	}
+}
+
+func parseBlockHash(s string) (string, error) {
+	h, err := ParseBlockHash(s)
+	return h.String(), err
+}
+
+func parseOperationHash(s string) (string, error) {
+	h, err := ParseOperationHash(s)
+	return h.String(), err
+}
+
+func parseProtocolHash(s string) (string, error) {
+	h, err := ParseProtocolHash(s)
+	return h.String(), err
+}
+
+func parseChainID(s string) (string, error) {
+	id, err := ParseChainID(s)
+	return id.String(), err
+}
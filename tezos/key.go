@@ -0,0 +1,147 @@
+package tezos
+
+import (
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+)
+
+// PublicKey is the public key of an implicit account (edpk, sppk or p2pk).
+type PublicKey struct {
+	curve ECKind
+	key   string
+}
+
+/*
+ParsePublicKey parses and validates a public key. Secp256k1 and NistP256 keys must be compressed points.
+
+Example:
+	pk, err := tezos.ParsePublicKey("edpkvS5QFv7KRGfa3b87gg9DBpxSm3NpSwnjhUjNBQrRUUR66F7C9g")
+*/
+func ParsePublicKey(s string) (PublicKey, error) {
+	v, e, err := decode("public key", s, edpk, sppk, p2pk)
+	if err != nil {
+		return PublicKey{}, err
+	}
+
+	if e.curve != Ed25519 && v[0] != 0x02 && v[0] != 0x03 {
+		return PublicKey{}, errors.Errorf("invalid public key '%s': not a compressed point", s)
+	}
+
+	return PublicKey{curve: e.curve, key: string(v)}, nil
+}
+
+// Curve returns the curve of the key.
+func (p PublicKey) Curve() ECKind {
+	return p.curve
+}
+
+// Bytes returns the raw bytes of the key.
+func (p PublicKey) Bytes() []byte {
+	return []byte(p.key)
+}
+
+// KeyHash returns the hash of the key, which is the address of its implicit account.
+func (p PublicKey) KeyHash() KeyHash {
+	k := KeyHash{curve: p.curve}
+	hash, _ := blake2b.New(len(k.hash), nil)
+	hash.Write([]byte(p.key))
+	copy(k.hash[:], hash.Sum(nil))
+
+	return k
+}
+
+// String returns the base58 encoded key.
+func (p PublicKey) String() string {
+	switch p.curve {
+	case Secp256k1:
+		return encode([]byte(p.key), sppk)
+	case NistP256:
+		return encode([]byte(p.key), p2pk)
+	}
+
+	return encode([]byte(p.key), edpk)
+}
+
+// MarshalText satisfies the encoding.TextMarshaler interface.
+func (p PublicKey) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText satisfies the encoding.TextUnmarshaler interface.
+func (p *PublicKey) UnmarshalText(v []byte) error {
+	parsed, err := ParsePublicKey(string(v))
+	if err != nil {
+		return err
+	}
+	*p = parsed
+
+	return nil
+}
+
+// Signature is a curve specific (edsig, spsig1 or p2sig) or generic (sig) signature.
+type Signature struct {
+	curve ECKind
+	sig   [64]byte
+}
+
+/*
+ParseSignature parses and validates a signature.
+
+Example:
+	sig, err := tezos.ParseSignature("edsigtrs8bK7vNfiR4Kd9dWasVa1bAWaQSu2ipnmLGZuwQa8ktCEMYVKqbWsbJ7zTS8dgYT9tiSUKorWCPFHosL5zPsiDwBQ6vb")
+*/
+func ParseSignature(s string) (Signature, error) {
+	v, e, err := decode("signature", s, edsig, spsig1, p2sig, sig)
+	if err != nil {
+		return Signature{}, err
+	}
+
+	signature := Signature{curve: e.curve}
+	copy(signature.sig[:], v)
+	return signature, nil
+}
+
+// Curve returns the curve of the signature, or an empty kind for a generic signature.
+func (s Signature) Curve() ECKind {
+	return s.curve
+}
+
+// IsGeneric reports whether the signature is a generic signature (sig), which is not tied to a curve.
+func (s Signature) IsGeneric() bool {
+	return s.curve == ""
+}
+
+// Bytes returns the 64 bytes of the signature.
+func (s Signature) Bytes() []byte {
+	return append([]byte{}, s.sig[:]...)
+}
+
+// String returns the base58 encoded signature.
+func (s Signature) String() string {
+	switch s.curve {
+	case Ed25519:
+		return encode(s.sig[:], edsig)
+	case Secp256k1:
+		return encode(s.sig[:], spsig1)
+	case NistP256:
+		return encode(s.sig[:], p2sig)
+	}
+
+	return encode(s.sig[:], sig)
+}
+
+// MarshalText satisfies the encoding.TextMarshaler interface.
+func (s Signature) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText satisfies the encoding.TextUnmarshaler interface.
+func (s *Signature) UnmarshalText(v []byte) error {
+	parsed, err := ParseSignature(string(v))
+	if err != nil {
+		return err
+	}
+	*s = parsed
+
+	return nil
+}
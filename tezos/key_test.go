@@ -0,0 +1,81 @@
+package tezos
+
+import (
+	"testing"
+
+	"github.com/goat-systems/go-tezos/v4/internal/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParsePublicKey(t *testing.T) {
+	type want struct {
+		err         bool
+		errContains string
+		curve       ECKind
+		keyHash     string
+	}
+
+	cases := []struct {
+		name  string
+		input string
+		want  want
+	}{
+		{"is successful with edpk", "edpkvS5QFv7KRGfa3b87gg9DBpxSm3NpSwnjhUjNBQrRUUR66F7C9g", want{false, "", Ed25519, "tz1KiAZmm1ShNEs38kxs5zk4AyLo2XssfTsT"}},
+		{"is successful with sppk", "sppk7ZZADMMS4cwsu3odb7BAu9mx3DZYHmXWWL9GNhKremaJXqytGBc", want{false, "", Secp256k1, "tz2TUwYWy5VP7ChX2xjXtGxxdfCnEQsotdeQ"}},
+		{"is successful with p2pk", "p2pk6594Hd4VEVPydvK67c2GVikNXWjLiv2tkPUVvd8XMAXqd4CYxdK", want{false, "", NistP256, "tz3fU9apdFnzoPhi4LB8AdxoiSVwLYM4kQ1F"}},
+		{"handles an uncompressed point", encode(append([]byte{0x04}, make([]byte, 32)...), sppk), want{true, "not a compressed point", "", ""}},
+		{"handles an invalid length", encode(make([]byte, 33), edpk), want{true, "invalid length (33!=32)", "", ""}},
+		{"handles an address", "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc", want{true, "unknown prefix, expected edpk, sppk, p2pk", "", ""}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			pk, err := ParsePublicKey(tt.input)
+			testutils.CheckErr(t, tt.want.err, tt.want.errContains, err)
+			if tt.want.err {
+				return
+			}
+
+			assert.Equal(t, tt.input, pk.String())
+			assert.Equal(t, tt.want.curve, pk.Curve())
+			assert.Equal(t, tt.want.keyHash, pk.KeyHash().String())
+			assert.Equal(t, tt.want.curve, pk.KeyHash().Curve())
+		})
+	}
+}
+
+func Test_ParseSignature(t *testing.T) {
+	type want struct {
+		err         bool
+		errContains string
+		curve       ECKind
+	}
+
+	cases := []struct {
+		name  string
+		input string
+		want  want
+	}{
+		{"is successful with edsig", "edsigtrs8bK7vNfiR4Kd9dWasVa1bAWaQSu2ipnmLGZuwQa8ktCEMYVKqbWsbJ7zTS8dgYT9tiSUKorWCPFHosL5zPsiDwBQ6vb", want{false, "", Ed25519}},
+		{"is successful with spsig1", "spsig1DH4YKRKTM2ZuHgwrru7rghPPag1HH44gEFm3xpaDwU9ysqff6c28dPotshCQN5CuGvtAQ2j7fxnpozuyftoDcGSXaqDsK", want{false, "", Secp256k1}},
+		{"is successful with p2sig", "p2sigqDiojrZAovJJzxWj9zPFevXgZ5aGvNW6CMxHRyNQQNiUaKcdCcv1KQHH7ssk2omEm9TpEXtbu6FYmgxoDumvrNMvHQMHW", want{false, "", NistP256}},
+		{"is successful with sig", encode(make([]byte, 64), sig), want{false, "", ""}},
+		{"handles an invalid length", encode(make([]byte, 63), edsig), want{true, "invalid length (63!=64)", ""}},
+		{"handles a public key", "edpkvS5QFv7KRGfa3b87gg9DBpxSm3NpSwnjhUjNBQrRUUR66F7C9g", want{true, "unknown prefix, expected edsig, spsig1, p2sig, sig", ""}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			signature, err := ParseSignature(tt.input)
+			testutils.CheckErr(t, tt.want.err, tt.want.errContains, err)
+			if tt.want.err {
+				return
+			}
+
+			assert.Equal(t, tt.input, signature.String())
+			assert.Equal(t, tt.want.curve, signature.Curve())
+			assert.Equal(t, tt.want.curve == "", signature.IsGeneric())
+			assert.Len(t, signature.Bytes(), 64)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Package tezos parses and validates the base58 encoded values of Tezos: addresses, key hashes, public keys,
+signatures, block hashes, operation hashes, protocol hashes and chain IDs. Every value type checks the checksum,
+prefix and length of its encoding when parsed, and encodes back to the same string.
+
+Example:
+	address, err := tezos.ParseAddress("KT1DrJV8vhkdLEj76h1H9Q4irZDqAkMPo1Qf%transfer")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(address.IsOriginated(), address.Entrypoint()) // true transfer
+*/
+package tezos
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/goat-systems/go-tezos/v4/internal/crypto"
+	"github.com/pkg/errors"
+)
+
+// ECKind is the elliptic curve of a key
+type ECKind string
+
+const (
+	// Ed25519 https://tools.ietf.org/html/rfc8032
+	Ed25519 ECKind = "Ed25519"
+	// Secp256k1 https://tools.ietf.org/html/rfc4492
+	Secp256k1 ECKind = "Secp256k1"
+	// NistP256 https://tools.ietf.org/html/rfc5656
+	NistP256 ECKind = "NistP256"
+)
+
+// encoding is a base58 prefix along with the length of the payload it prefixes
+type encoding struct {
+	name   string
+	prefix []byte
+	length int
+	curve  ECKind
+}
+
+var (
+	tz1 = encoding{name: "tz1", prefix: []byte{6, 161, 159}, length: 20, curve: Ed25519}
+	tz2 = encoding{name: "tz2", prefix: []byte{6, 161, 161}, length: 20, curve: Secp256k1}
+	tz3 = encoding{name: "tz3", prefix: []byte{6, 161, 164}, length: 20, curve: NistP256}
+	kt1 = encoding{name: "KT1", prefix: []byte{2, 90, 121}, length: 20}
+
+	edpk = encoding{name: "edpk", prefix: []byte{13, 15, 37, 217}, length: 32, curve: Ed25519}
+	sppk = encoding{name: "sppk", prefix: []byte{3, 254, 226, 86}, length: 33, curve: Secp256k1}
+	p2pk = encoding{name: "p2pk", prefix: []byte{3, 178, 139, 127}, length: 33, curve: NistP256}
+
+	edsig  = encoding{name: "edsig", prefix: []byte{9, 245, 205, 134, 18}, length: 64, curve: Ed25519}
+	spsig1 = encoding{name: "spsig1", prefix: []byte{13, 115, 101, 19, 63}, length: 64, curve: Secp256k1}
+	p2sig  = encoding{name: "p2sig", prefix: []byte{54, 240, 44, 52}, length: 64, curve: NistP256}
+	sig    = encoding{name: "sig", prefix: []byte{4, 130, 43}, length: 64}
+
+	blockHash     = encoding{name: "B", prefix: []byte{1, 52}, length: 32}
+	operationHash = encoding{name: "o", prefix: []byte{5, 116}, length: 32}
+	protocolHash  = encoding{name: "P", prefix: []byte{2, 170}, length: 32}
+	chainID       = encoding{name: "Net", prefix: []byte{87, 82, 0}, length: 4}
+)
+
+// decode decodes the base58 value s of one of encodings, checking its checksum, prefix and payload length
+func decode(what string, s string, encodings ...encoding) ([]byte, encoding, error) {
+	v, err := crypto.Decode(s)
+	if err != nil {
+		return nil, encoding{}, errors.Wrapf(err, "invalid %s '%s'", what, s)
+	}
+
+	names := make([]string, len(encodings))
+	for i, e := range encodings {
+		names[i] = e.name
+		if !bytes.HasPrefix(v, e.prefix) {
+			continue
+		}
+
+		if l := len(v) - len(e.prefix); l != e.length {
+			return nil, e, errors.Errorf("invalid %s '%s': invalid length (%d!=%d)", what, s, l, e.length)
+		}
+
+		return v[len(e.prefix):], e, nil
+	}
+
+	return nil, encoding{}, errors.Errorf("invalid %s '%s': unknown prefix, expected %s", what, s, strings.Join(names, ", "))
+}
+
+func encode(payload []byte, e encoding) string {
+	return crypto.B58cencode(payload, e.prefix)
+}